@@ -0,0 +1,73 @@
+// tdd-guard-go-exec runs the test binaries of go test -exec, saving the
+// results of each package for TDD Guard:
+//
+//	go test -exec tdd-guard-go-exec ./...
+//
+// Output and exit codes pass through unchanged, so go test reports the run
+// as usual. Settings come from the config file and TDD_GUARD_GO_*
+// environment variables, as for tdd-guard-go.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
+	"github.com/nizos/tdd-guard/reporters/go/internal/testbin"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the binary named by args and returns its exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: go test -exec tdd-guard-go-exec [packages]")
+		return 2
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = stdin
+
+	var code int
+	var err error
+	if isTestBinary(args[0]) {
+		code, err = runTestBinary(cmd, args[0], stdout, stderr)
+	} else {
+		// go run also accepts -exec, its programs run unchanged
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+		code, err = testbin.ExitCode(cmd.Run())
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "tdd-guard-go-exec: %v\n", err)
+	}
+	return code
+}
+
+// runTestBinary runs the test binary of cmd and saves the results of its
+// package. Invalid settings are reported and the binary runs unreported.
+func runTestBinary(cmd *exec.Cmd, binary string, stdout, stderr io.Writer) (int, error) {
+	reporter, err := config.LoadReporter("tdd-guard-go-exec")
+	if err != nil {
+		fmt.Fprintf(stderr, "tdd-guard-go-exec: %v\n", err)
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+		return testbin.ExitCode(cmd.Run())
+	}
+
+	// go test runs each binary in its package directory, which names the package
+	if err := reporter.SetPackage(binary); err != nil {
+		fmt.Fprintf(stderr, "tdd-guard-go-exec: warning: %v, reporting its tests as %s\n", err, reporter.Package())
+	}
+	return testbin.Run(cmd, reporter, stdout)
+}
+
+// isTestBinary checks if path names a binary built by go test
+func isTestBinary(path string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(filepath.Base(path), ".exe"), ".test")
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/tddguard"
+)
+
+// helperEnv makes the helper test run when this binary runs it itself
+const helperEnv = "TDD_GUARD_GO_EXEC_HELPER"
+
+func TestRun(t *testing.T) {
+	t.Run("saves the results of the test binary's package", func(t *testing.T) {
+		root := inProject(t)
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{os.Args[0], "-test.run=^TestHelper$"}, nil, &stdout, &stderr)
+
+		if code != 0 || stdout.String() != "PASS\n" || stderr.Len() > 0 {
+			t.Errorf("Expected a passing run, got exit code %d, output %q and errors %q", code, stdout.String(), stderr.String())
+		}
+		result := readSaved(t, root)
+		if result.TestModules[0].ModuleID != "tdd-guard-go-exec" || result.TestModules[0].Tests[0].Name != "TestHelper" {
+			t.Errorf("Expected TestHelper in tdd-guard-go-exec, got %+v", result)
+		}
+	})
+
+	t.Run("finds the package of a major version module", func(t *testing.T) {
+		root := t.TempDir()
+		os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/tdd-guard-go-exec/v2\n"), 0644)
+		os.WriteFile(filepath.Join(root, "main_test.go"), []byte("package main\n"), 0644)
+		t.Setenv("CLAUDE_PROJECT_DIR", root)
+		t.Setenv(helperEnv, "pass")
+		t.Chdir(root)
+		var stderr bytes.Buffer
+
+		run([]string{os.Args[0], "-test.run=^TestHelper$"}, nil, &bytes.Buffer{}, &stderr)
+
+		if stderr.Len() > 0 {
+			t.Errorf("Expected no errors, got %q", stderr.String())
+		}
+		if result := readSaved(t, root); result.TestModules[0].ModuleID != "example.com/tdd-guard-go-exec/v2" {
+			t.Errorf("Expected the v2 module package, got %+v", result)
+		}
+	})
+
+	t.Run("warns when the package of the binary is unknown", func(t *testing.T) {
+		root := inProject(t)
+		t.Chdir(root)
+		os.RemoveAll(filepath.Join(root, "tdd-guard-go-exec"))
+		var stderr bytes.Buffer
+
+		run([]string{os.Args[0], "-test.run=^TestHelper$"}, nil, &bytes.Buffer{}, &stderr)
+
+		if !bytes.Contains(stderr.Bytes(), []byte("warning: cannot find the package of test binary")) ||
+			!bytes.Contains(stderr.Bytes(), []byte(tddguard.DefaultPackage)) {
+			t.Errorf("Expected a warning naming the fallback package, got %q", stderr.String())
+		}
+	})
+
+	t.Run("runs the binary unreported with invalid settings", func(t *testing.T) {
+		root := inProject(t)
+		t.Setenv("TDD_GUARD_GO_INCLUDE_TESTS", "re:(")
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{os.Args[0], "-test.run=^TestHelper$"}, nil, &stdout, &stderr)
+
+		if code != 0 || stdout.String() != "PASS\n" || !bytes.HasPrefix(stderr.Bytes(), []byte("tdd-guard-go-exec: invalid test pattern")) {
+			t.Errorf("Expected a passing run with an error, got exit code %d, output %q and errors %q", code, stdout.String(), stderr.String())
+		}
+		if _, err := os.Stat(filepath.Join(root, ".claude", "tdd-guard", "data", "test.json")); err == nil {
+			t.Error("Expected no saved results")
+		}
+	})
+
+	t.Run("passes through the exit code of failed tests", func(t *testing.T) {
+		inProject(t)
+		t.Setenv(helperEnv, "fail")
+		var stdout bytes.Buffer
+
+		code := run([]string{os.Args[0], "-test.run=^TestHelper$"}, nil, &stdout, &bytes.Buffer{})
+
+		if code != 1 || !bytes.Contains(stdout.Bytes(), []byte("--- FAIL: TestHelper")) {
+			t.Errorf("Expected exit code 1 with the failure, got %d and %q", code, stdout.String())
+		}
+	})
+
+	t.Run("runs other programs unchanged", func(t *testing.T) {
+		root := inProject(t)
+		program := filepath.Join(t.TempDir(), "program")
+		data, _ := os.ReadFile(os.Args[0])
+		os.WriteFile(program, data, 0755)
+		var stdout bytes.Buffer
+
+		code := run([]string{program, "-test.run=^TestHelper$"}, nil, &stdout, &bytes.Buffer{})
+
+		if code != 0 || stdout.String() != "PASS\n" {
+			t.Errorf("Expected unchanged output, got exit code %d and %q", code, stdout.String())
+		}
+		if _, err := os.Stat(filepath.Join(root, ".claude", "tdd-guard", "data", "test.json")); err == nil {
+			t.Error("Expected no saved results")
+		}
+	})
+
+	t.Run("prints usage without a binary", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		if code := run(nil, nil, &bytes.Buffer{}, &stderr); code != 2 || stderr.Len() == 0 {
+			t.Errorf("Expected usage with exit code 2, got %d and %q", code, stderr.String())
+		}
+	})
+}
+
+func TestHelper(t *testing.T) {
+	switch os.Getenv(helperEnv) {
+	case "":
+		t.Skip("runs in the test binary started by TestRun")
+	case "fail":
+		t.Error("helper failed")
+	}
+}
+
+// Helper functions
+
+// inProject changes to a package directory named like this test binary,
+// in a project that saves results under the returned root
+func inProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "tdd-guard-go-exec")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "main_test.go"), []byte("package main\n"), 0644)
+	t.Setenv("CLAUDE_PROJECT_DIR", root)
+	t.Setenv(helperEnv, "pass")
+	t.Chdir(dir)
+	return root
+}
+
+func readSaved(t *testing.T, root string) *tddguard.TestResult {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, ".claude", "tdd-guard", "data", "test.json"))
+	if err != nil {
+		t.Fatalf("Expected saved results: %v", err)
+	}
+	var result *tddguard.TestResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Invalid saved results: %v", err)
+	}
+	return result
+}
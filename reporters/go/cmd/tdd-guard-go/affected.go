@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/affected"
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
+	"github.com/nizos/tdd-guard/reporters/go/internal/runner"
+)
+
+// runAffected implements the affected subcommand: it runs go test only in
+// packages affected by the working tree changes and records the others as
+// not run
+func runAffected(args []string, stdout, stderr io.Writer) int {
+	setup, err := loadRunSetup("tdd-guard-go affected", args, stderr)
+	if err != nil {
+		return reportError(err, stderr)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	changed, err := affected.ChangedFiles(ctx, setup.root)
+	if err != nil {
+		return reportError(fmt.Errorf("find changed files: %w", err), stderr)
+	}
+	graph := affected.NewGraph(setup.modules)
+	if err := graph.Load(ctx, goCommand); err != nil {
+		return reportError(fmt.Errorf("load import graph: %w", err), stderr)
+	}
+
+	jobs, notRun := affectedJobs(setup, graph.Select(changed))
+	if len(jobs) == 0 {
+		if setup.cfg.Verbosity != config.VerbosityQuiet {
+			fmt.Fprintln(stdout, "tdd-guard-go: no packages affected by changes")
+		}
+		return 0
+	}
+
+	results := newRunner(setup.cfg).RunJobs(ctx, jobs)
+	if err := processRun(bytes.NewReader(runner.Combine(results)), setup.cfg, stdout, notRun); err != nil {
+		return reportError(err, stderr)
+	}
+	if runner.Failed(results) {
+		return 1
+	}
+	return 0
+}
+
+// affectedJobs builds a go test job for every module with affected packages
+// and lists the packages left out
+func affectedJobs(setup *runSetup, selections []affected.Selection) ([]runner.Job, []string) {
+	var jobs []runner.Job
+	var notRun []string
+	for _, selection := range selections {
+		notRun = append(notRun, selection.NotRun...)
+		if len(selection.Affected) > 0 {
+			args := append(append([]string{}, setup.goArgs...), selection.Affected...)
+			jobs = append(jobs, runner.Job{Module: selection.Module, Args: args})
+		}
+	}
+	return jobs, notRun
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAffected(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("CLAUDE_PROJECT_DIR", "")
+	t.Setenv("GOFLAGS", "")
+	root, _ := filepath.EvalSymlinks(t.TempDir())
+	for name, content := range map[string]string{
+		"app/go.mod":                "module example.com/app\n\ngo 1.24\n",
+		"app/app.go":                "package app\n\nimport _ \"example.com/app/calc\"\n",
+		"app/app_test.go":           "package app\n\nimport \"testing\"\n\nfunc TestApp(t *testing.T) {}\n",
+		"app/calc/calc.go":          "package calc\n",
+		"app/calc/calc_test.go":     "package calc\n\nimport \"testing\"\n\nfunc TestCalc(t *testing.T) {}\n",
+		"app/report/report_test.go": "package report\n\nimport \"testing\"\n\nfunc TestReport(t *testing.T) {}\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	t.Chdir(filepath.Join(root, "app"))
+	projectRoot := filepath.Join(root, "app")
+
+	t.Run("reports when nothing is affected", func(t *testing.T) {
+		var stdout bytes.Buffer
+		code := run([]string{"affected"}, strings.NewReader(""), &stdout, io.Discard)
+
+		if code != 0 || !strings.Contains(stdout.String(), "no packages affected") {
+			t.Errorf("Expected no affected packages, got code %d: %s", code, stdout.String())
+		}
+	})
+
+	t.Run("runs changed packages and their importers", func(t *testing.T) {
+		os.WriteFile(filepath.Join(root, "app", "calc", "calc.go"), []byte("package calc\n\n// changed\n"), 0644)
+
+		code := run([]string{"affected"}, strings.NewReader(""), io.Discard, io.Discard)
+
+		if code != 0 {
+			t.Errorf("Expected exit code 0, got %d", code)
+		}
+		data, _ := os.ReadFile(getTestFilePath(projectRoot))
+		for _, expected := range []string{`"name":"TestCalc"`, `"name":"TestApp"`, `"notRun":["report"]`} {
+			if !bytes.Contains(data, []byte(expected)) {
+				t.Errorf("Expected %s in results, got: %s", expected, data)
+			}
+		}
+		if bytes.Contains(data, []byte(`"name":"TestReport"`)) {
+			t.Errorf("Expected unaffected package not to run, got: %s", data)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
+)
+
+// runConfig implements the config subcommand
+func runConfig(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "show" {
+		fmt.Fprintln(stderr, "usage: tdd-guard-go config show [flags]")
+		return 2
+	}
+
+	cfg, _, err := config.Load("tdd-guard-go config show", args[1:], stderr)
+	if err != nil {
+		return reportError(err, stderr)
+	}
+
+	if cfg.ConfigFile != "" {
+		fmt.Fprintf(stdout, "Config file: %s\n\n", cfg.ConfigFile)
+	}
+	if err := cfg.Show(stdout); err != nil {
+		return reportError(err, stderr)
+	}
+	return 0
+}
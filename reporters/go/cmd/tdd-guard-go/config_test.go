@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfig(t *testing.T) {
+	t.Setenv("CLAUDE_PROJECT_DIR", "")
+	t.Setenv("TDD_GUARD_GO_MERGE", "")
+	tempDir, _ := filepath.EvalSymlinks(t.TempDir())
+	os.WriteFile(filepath.Join(tempDir, "tdd-guard.toml"), []byte("[tool.tdd-guard-go]\nmerge = true\n"), 0644)
+	t.Chdir(tempDir)
+
+	t.Run("show prints effective settings with their source", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"config", "show"}, strings.NewReader(""), &stdout, &stderr)
+
+		if code != 0 {
+			t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		configFile := filepath.Join(tempDir, "tdd-guard.toml")
+		for _, expected := range []string{"Config file: " + configFile, "merge", "file " + configFile} {
+			if !strings.Contains(stdout.String(), expected) {
+				t.Errorf("Expected output to contain %q, got:\n%s", expected, stdout.String())
+			}
+		}
+	})
+
+	t.Run("show applies flags", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		run([]string{"config", "show", "-verbosity", "quiet"}, strings.NewReader(""), &stdout, &stderr)
+
+		if !strings.Contains(stdout.String(), "flag -verbosity") {
+			t.Errorf("Expected flag source in output, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("requires a subcommand", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"config"}, strings.NewReader(""), &stdout, &stderr)
+
+		if code != 2 || !strings.Contains(stderr.String(), "usage") {
+			t.Errorf("Expected usage error, got code %d: %s", code, stderr.String())
+		}
+	})
+
+	t.Run("reports invalid settings on stderr", func(t *testing.T) {
+		t.Setenv("TDD_GUARD_GO_FORMAT", "fancy")
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"config", "show"}, strings.NewReader(""), &stdout, &stderr)
+
+		if code != 1 || !strings.Contains(stderr.String(), "TDD_GUARD_GO_FORMAT") {
+			t.Errorf("Expected invalid setting error, got code %d: %s", code, stderr.String())
+		}
+	})
+}
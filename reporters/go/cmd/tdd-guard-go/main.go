@@ -3,125 +3,197 @@ package main
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"strings"
 
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
 	"github.com/nizos/tdd-guard/reporters/go/internal/formatter"
 	tddio "github.com/nizos/tdd-guard/reporters/go/internal/io"
 	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
-	"github.com/nizos/tdd-guard/reporters/go/internal/storage"
-	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+	"github.com/nizos/tdd-guard/reporters/go/tddguard"
 )
 
 func main() {
-	var projectRoot string
-	flag.StringVar(&projectRoot, "project-root", "", "Project root directory (absolute path)")
-	flag.Parse()
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
 
-	if err := process(os.Stdin, projectRoot, os.Stdout); err != nil {
-		os.Exit(1)
+// run dispatches subcommands and returns the process exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "config":
+			return runConfig(args[1:], stdout, stderr)
+		case "run":
+			return runTests(args[1:], stdout, stderr)
+		case "affected":
+			return runAffected(args[1:], stdout, stderr)
+		case "watch":
+			return runWatch(args[1:], stdout, stderr)
+		}
 	}
-}
 
-func process(input io.Reader, projectRoot string, output io.Writer) error {
-	if err := validateProjectRoot(projectRoot); err != nil {
-		return err
+	cfg, _, err := config.Load("tdd-guard-go", args, stderr)
+	if err != nil {
+		return reportError(err, stderr)
 	}
 
-	// Buffer to collect input
-	buffer := &bytes.Buffer{}
-	teeReader := tddio.NewTeeReader(input, buffer)
+	if err := processWithConfig(stdin, cfg, stdout); err != nil {
+		return reportError(err, stderr)
+	}
+	return 0
+}
 
-	// Read all input to buffer
-	io.ReadAll(teeReader)
+// reportError prints err and returns the matching exit code.
+// Flag parsing errors have already been printed along with the usage.
+func reportError(err error, stderr io.Writer) int {
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, config.ErrUsage):
+		return 2
+	}
+	fmt.Fprintf(stderr, "tdd-guard-go: %v\n", err)
+	return 1
+}
 
-	// Format and output
-	formatAndOutput(bytes.NewReader(buffer.Bytes()), output)
+func process(input io.Reader, projectRoot string, output io.Writer) error {
+	cfg := config.Default()
+	cfg.ProjectRoot = projectRoot
+	return processWithConfig(input, cfg, output)
+}
+
+func processWithConfig(input io.Reader, cfg *config.Config, output io.Writer) error {
+	return processRun(input, cfg, output, nil)
+}
 
-	// Parse test output from buffer
-	mixedReader := parser.NewMixedReader(buffer)
-	results, p, err := parseTestResults(mixedReader)
+// processRun reports the test output of a run that intentionally left out
+// the notRun packages
+func processRun(input io.Reader, cfg *config.Config, output io.Writer, notRun []string) error {
+	projectRoot, err := tddguard.ResolveRoot(cfg.ProjectRoot)
 	if err != nil {
 		return err
 	}
+	cfg.ProjectRoot = projectRoot
 
-	// Add synthetic test for compilation errors
-	if shouldAddCompilationError(results, mixedReader.CompilationError) {
-		addCompilationError(results, mixedReader.CompilationError)
+	reporter, err := cfg.NewReporter()
+	if err != nil {
+		return err
 	}
+	reporter.SetNotRun(notRun)
 
-	// Transform and save results
-	t := transformer.NewTransformer()
-	result := t.Transform(results, p, mixedReader.CompilationError)
-
-	s := storage.NewStorage(projectRoot)
-	return s.Save(result)
-}
+	// Output is printed as it arrives and collected for the results
+	buffer := &bytes.Buffer{}
+	teeReader := tddio.NewTeeReader(input, buffer)
 
-func formatAndOutput(input io.Reader, output io.Writer) {
-	f := formatter.NewFormatter()
-	scanner := bufio.NewScanner(input)
+	color := formatter.UseColor(formatter.ColorMode(cfg.Color), output)
+	if cfg.Format == config.FormatRaw {
+		_, err = io.Copy(output, teeReader)
+	} else {
+		f := formatter.NewFormatterWithStyle(formatter.Style(cfg.Style))
+		f.SetColor(color)
+		err = formatAndOutput(teeReader, output, f, reporter.Package())
+	}
+	if err != nil {
+		return fmt.Errorf("read test output: %w", err)
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	// Filtered results still appear in the terminal output above
+	if err := reporter.Read(buffer); err != nil {
+		return err
+	}
+	result, err := reporter.Finish()
+	if err != nil {
+		return err
+	}
 
-		// Try to parse as JSON
-		var event parser.TestEvent
-		if err := json.Unmarshal([]byte(line), &event); err == nil {
-			// It's JSON - format it
-			formatted := f.Format(event)
-			if formatted != "" {
-				fmt.Fprintln(output, formatted)
+	// Raw output stays identical to the input so it can be piped further
+	if cfg.Verbosity != config.VerbosityQuiet && cfg.Format != config.FormatRaw {
+		if cfg.Summary {
+			if summary := formatter.FormatSummary(result, reporter.WallTime(), cfg.Slowest); summary != "" {
+				if color {
+					summary = formatter.Colorize(summary)
+				}
+				fmt.Fprintln(output, summary)
 			}
-		} else {
-			// Not JSON - pass through as-is
-			fmt.Fprintln(output, line)
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(output, "tdd-guard-go: warning: %s\n", warning)
+		}
+		if summary := formatter.FormatTransitions(result.Transitions); summary != "" {
+			fmt.Fprintln(output, summary)
+		}
+		if reporter.HasUnnamedEvents() && cfg.Package == "" {
+			fmt.Fprintf(output, "tdd-guard-go: events without a package were reported as %s, set -package to name it\n", tddguard.DefaultPackage)
+		}
+		if filtered := reporter.Filtered(); filtered != "" {
+			fmt.Fprintf(output, "tdd-guard-go: %s from results\n", filtered)
 		}
 	}
-}
-
-func validateProjectRoot(projectRoot string) error {
-	if projectRoot == "" {
-		return nil
-	}
-
-	if !filepath.IsAbs(projectRoot) {
-		return errors.New("project root must be an absolute path")
+	if cfg.Verbosity == config.VerbosityVerbose {
+		fmt.Fprintf(output, "tdd-guard-go: saved test results to %s\n", reporter.ResultsPath())
 	}
-
-	cwd, _ := os.Getwd()
-	if !strings.HasPrefix(cwd, projectRoot) {
-		return errors.New("current directory must be within project root")
+	slowTests, slowPackages := countSlow(result)
+	switch {
+	case !cfg.SlowStrict:
+	case slowTests == 1:
+		return errors.New("1 slow test exceeded its threshold")
+	case slowTests > 1:
+		return fmt.Errorf("%d slow tests exceeded their threshold", slowTests)
+	case slowPackages == 1:
+		return errors.New("1 package exceeded its slow budget")
+	case slowPackages > 1:
+		return fmt.Errorf("%d packages exceeded their slow budget", slowPackages)
 	}
-
 	return nil
 }
 
-func parseTestResults(mixedReader *parser.MixedReader) (parser.Results, *parser.Parser, error) {
-	p := parser.NewParser()
-
-	jsonInput := strings.Join(mixedReader.JSONLines, "\n")
-	if jsonInput != "" {
-		if err := p.Parse(strings.NewReader(jsonInput)); err != nil {
-			return nil, nil, err
+// countSlow counts the tests flagged as slow and the packages failed for
+// exceeding their budget
+func countSlow(result *tddguard.TestResult) (tests, packages int) {
+	for _, module := range result.TestModules {
+		for _, test := range module.Tests {
+			switch {
+			case test.Name == tddguard.SlowPackageTest:
+				packages++
+			case test.Slow:
+				tests++
+			}
 		}
 	}
-
-	return p.GetResults(), p, nil
+	return tests, packages
 }
 
-func shouldAddCompilationError(results parser.Results, compilationError *parser.CompilationError) bool {
-	return len(results) == 0 && compilationError != nil
-}
-
-func addCompilationError(results parser.Results, compilationError *parser.CompilationError) {
-	results[compilationError.Package] = parser.PackageResults{
-		"CompilationError": parser.StateFailed,
+// formatAndOutput formats JSON events and passes other lines through as
+// they are read. Events without a package are shown as events of pkg.
+func formatAndOutput(input io.Reader, output io.Writer, f *formatter.Formatter, pkg string) error {
+	reader := bufio.NewReader(input)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+
+			// Try to parse as JSON
+			if event, ok := parser.DecodeEvent(line); ok {
+				// It's JSON - format it
+				if event.Package == "" && event.ImportPath == "" {
+					event.Package = pkg
+				}
+				f.Write(output, event)
+			} else {
+				// Not JSON - pass through as-is
+				f.WriteLine(output, line)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
 	}
 }
@@ -5,13 +5,17 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
 	"github.com/nizos/tdd-guard/reporters/go/internal/storage"
 )
 
 func TestProcess(t *testing.T) {
 	// Setup temp directory for all tests
+	t.Setenv("CLAUDE_PROJECT_DIR", "")
 	oldWd, _ := os.Getwd()
 	tempDir := t.TempDir()
 	os.Chdir(tempDir)
@@ -26,6 +30,32 @@ func TestProcess(t *testing.T) {
 		})
 	})
 
+	t.Run("without project root in nested directory", func(t *testing.T) {
+		t.Run("saves results at the discovered project root", func(t *testing.T) {
+			projectDir := t.TempDir()
+			nestedDir := filepath.Join(projectDir, "internal", "pkg")
+			os.MkdirAll(nestedDir, 0755)
+			os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/module\n"), 0644)
+			os.Chdir(nestedDir)
+			defer os.Chdir(tempDir)
+
+			runProcess(t, "")
+			assertFileExists(t, projectDir)
+		})
+
+		t.Run("saves results in CLAUDE_PROJECT_DIR", func(t *testing.T) {
+			nestedDir := filepath.Join(tempDir, "nested")
+			os.MkdirAll(nestedDir, 0755)
+			os.Chdir(nestedDir)
+			defer os.Chdir(tempDir)
+			t.Setenv("CLAUDE_PROJECT_DIR", tempDir)
+			clearResults(t, tempDir)
+
+			runProcess(t, "")
+			assertFileExists(t, tempDir)
+		})
+	})
+
 	t.Run("with valid project root", func(t *testing.T) {
 		t.Run("uses provided project root", func(t *testing.T) {
 			runProcess(t, tempDir)
@@ -71,12 +101,80 @@ func TestProcess(t *testing.T) {
 		})
 	})
 
+	t.Run("source locations", func(t *testing.T) {
+		t.Run("reports package directory and test location", func(t *testing.T) {
+			projectDir := t.TempDir()
+			pkgDir := filepath.Join(projectDir, "internal", "calc")
+			os.MkdirAll(pkgDir, 0755)
+			os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/app\n"), 0644)
+			os.WriteFile(filepath.Join(pkgDir, "calc_test.go"), []byte("package calc\n\nfunc TestAdd(t *testing.T) {}\n"), 0644)
+			os.Chdir(pkgDir)
+			defer os.Chdir(tempDir)
+
+			input := `{"Action":"pass","Package":"example.com/app/internal/calc","Test":"TestAdd"}`
+			data := processAndReadOutput(t, input, projectDir)
+
+			if !bytes.Contains(data, []byte(`"moduleId":"internal/calc"`)) {
+				t.Errorf("Expected package directory as moduleId, got: %s", data)
+			}
+			if !bytes.Contains(data, []byte(`"file":"internal/calc/calc_test.go","line":3`)) {
+				t.Errorf("Expected test file, got: %s", data)
+			}
+		})
+	})
+
+	t.Run("cached results", func(t *testing.T) {
+		t.Run("warns when testdata changed since the last run", func(t *testing.T) {
+			projectDir := t.TempDir()
+			pkgDir := filepath.Join(projectDir, "calc")
+			os.MkdirAll(filepath.Join(pkgDir, "testdata"), 0755)
+			os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/app\n"), 0644)
+			os.WriteFile(filepath.Join(pkgDir, "calc_test.go"), []byte("package calc\n"), 0644)
+			os.Chdir(projectDir)
+			defer os.Chdir(tempDir)
+
+			input := strings.Join([]string{
+				`{"Action":"pass","Package":"example.com/app/calc","Test":"TestAdd"}`,
+				`{"Action":"output","Package":"example.com/app/calc","Output":"ok  \texample.com/app/calc\t(cached)\n"}`,
+				`{"Action":"pass","Package":"example.com/app/calc"}`,
+			}, "\n")
+			processAndReadOutput(t, input, projectDir)
+			lastRun := time.Now().Add(-time.Minute)
+			os.Chtimes(getTestFilePath(projectDir), lastRun, lastRun)
+			os.WriteFile(filepath.Join(pkgDir, "testdata", "input.txt"), []byte("changed"), 0644)
+
+			output := &bytes.Buffer{}
+			if err := process(strings.NewReader(input), projectDir, output); err != nil {
+				t.Fatal(err)
+			}
+			data, _ := os.ReadFile(getTestFilePath(projectDir))
+
+			if !strings.Contains(output.String(), "tdd-guard-go: warning: example.com/app/calc: cached result may be stale, testdata/input.txt changed") {
+				t.Errorf("Expected warning in output, got:\n%s", output)
+			}
+			if !bytes.Contains(data, []byte(`"cached":true`)) || !bytes.Contains(data, []byte(`"warnings":["example.com/app/calc`)) {
+				t.Errorf("Expected cached module and warning in results, got: %s", data)
+			}
+		})
+	})
+
 	t.Run("project root validation", func(t *testing.T) {
 		t.Run("rejects relative project root", func(t *testing.T) {
 			err := runProcess(t, "../relative/path")
 			assertErrorContains(t, err, "project root must be an absolute path")
 		})
 
+		t.Run("rejects sibling directory sharing a name prefix", func(t *testing.T) {
+			siblingRoot := tempDir + "-sibling"
+			os.MkdirAll(siblingRoot, 0755)
+			defer os.RemoveAll(siblingRoot)
+			os.Chdir(siblingRoot)
+			defer os.Chdir(tempDir)
+
+			err := runProcess(t, tempDir)
+			assertErrorContains(t, err, "current directory must be within project root")
+		})
+
 		t.Run("rejects project root outside current directory", func(t *testing.T) {
 			outsideRoot := filepath.Join(filepath.Dir(tempDir), "outside")
 			os.MkdirAll(outsideRoot, 0755)
@@ -86,8 +184,23 @@ func TestProcess(t *testing.T) {
 		})
 	})
 
+	t.Run("saving results", func(t *testing.T) {
+		t.Run("returns error when results cannot be written", func(t *testing.T) {
+			projectDir := filepath.Join(tempDir, "unwritable")
+			os.MkdirAll(projectDir, 0755)
+			os.WriteFile(filepath.Join(projectDir, ".claude"), []byte("not a directory"), 0644)
+			os.Chdir(projectDir)
+			defer os.Chdir(tempDir)
+
+			if err := runProcess(t, projectDir); err == nil {
+				t.Fatal("Expected error when results cannot be written")
+			}
+		})
+	})
+
 	t.Run("formatted output", func(t *testing.T) {
 		t.Run("formats package pass event", func(t *testing.T) {
+			clearResults(t, tempDir)
 			input := `{"Action":"pass","Package":"example.com/pkg","Elapsed":0.003}`
 			output := &bytes.Buffer{}
 
@@ -103,6 +216,7 @@ func TestProcess(t *testing.T) {
 		})
 
 		t.Run("passes through compilation errors", func(t *testing.T) {
+			clearResults(t, tempDir)
 			input := "# command-line-arguments"
 			output := &bytes.Buffer{}
 
@@ -111,13 +225,40 @@ func TestProcess(t *testing.T) {
 				t.Fatalf("Expected no error, got: %v", err)
 			}
 
-			expected := "# command-line-arguments\n"
-			if output.String() != expected {
-				t.Errorf("Expected '%s', got '%s'", expected, output.String())
+			expected := "# command-line-arguments\n\n⎯⎯⎯⎯⎯⎯⎯ Build Failures 1 ⎯⎯⎯⎯⎯⎯⎯\n FAIL  command-line-arguments\n"
+			if !strings.HasPrefix(output.String(), expected) {
+				t.Errorf("Expected '%s' followed by the summary, got '%s'", expected, output.String())
+			}
+		})
+
+		t.Run("ends with a summary of the run", func(t *testing.T) {
+			clearResults(t, tempDir)
+			input := `{"Action":"fail","Package":"example.com/pkg","Test":"TestExample"}`
+			output := &bytes.Buffer{}
+
+			process(bytes.NewReader([]byte(input)), tempDir, output)
+
+			expected := " Packages  1 failed (1)\n    Tests  1 failed (1)\n Duration  0.00s\n"
+			if !strings.HasSuffix(output.String(), expected) {
+				t.Errorf("Expected output to end with '%s', got '%s'", expected, output.String())
+			}
+		})
+
+		t.Run("omits the summary when disabled", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.Summary = false
+			output := &bytes.Buffer{}
+
+			processWithConfig(strings.NewReader(`{"Action":"fail","Package":"example.com/pkg","Test":"TestExample"}`), cfg, output)
+
+			if strings.Contains(output.String(), "Packages") {
+				t.Errorf("Expected no summary, got '%s'", output.String())
 			}
 		})
 
 		t.Run("filters out JSON start events", func(t *testing.T) {
+			clearResults(t, tempDir)
 			input := `{"Action":"start","Package":"example.com/pkg"}`
 			output := &bytes.Buffer{}
 
@@ -132,6 +273,334 @@ func TestProcess(t *testing.T) {
 		})
 	})
 
+	t.Run("transitions", func(t *testing.T) {
+		t.Run("reports tests that changed state since the last run", func(t *testing.T) {
+			clearResults(t, tempDir)
+			runProcess(t, tempDir)
+
+			input := `{"Action":"fail","Package":"example.com/pkg","Test":"TestExample"}`
+			output := &bytes.Buffer{}
+			if err := process(bytes.NewReader([]byte(input)), tempDir, output); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			expected := "Since last run: 1 newly failed\n  newly failed: example.com/pkg/TestExample\n"
+			if !strings.HasSuffix(output.String(), expected) {
+				t.Errorf("Expected output to end with '%s', got '%s'", expected, output.String())
+			}
+		})
+
+		t.Run("saves transitions in results", func(t *testing.T) {
+			clearResults(t, tempDir)
+			runProcess(t, tempDir)
+
+			input := `{"Action":"fail","Package":"example.com/pkg","Test":"TestExample"}`
+			data := processAndReadOutput(t, input, tempDir)
+
+			if !bytes.Contains(data, []byte(`"transitions":{"newlyFailed":["example.com/pkg/TestExample"]}`)) {
+				t.Fatalf("Expected transitions in output, got: %s", data)
+			}
+		})
+
+		t.Run("omits transitions on first run", func(t *testing.T) {
+			clearResults(t, tempDir)
+			data := processAndReadOutput(t, `{"Action":"pass","Package":"example.com/pkg","Test":"TestExample"}`, tempDir)
+
+			if bytes.Contains(data, []byte(`"transitions"`)) {
+				t.Fatalf("Expected no transitions on first run, got: %s", data)
+			}
+		})
+	})
+
+	t.Run("merge mode", func(t *testing.T) {
+		mergeConfig := config.Default()
+		mergeConfig.ProjectRoot = tempDir
+		mergeConfig.Merge = true
+		mergeConfig.MaxAge = time.Hour
+
+		t.Run("keeps results of packages that did not run", func(t *testing.T) {
+			clearResults(t, tempDir)
+			processWithConfig(strings.NewReader(`{"Action":"pass","Package":"example.com/a","Test":"TestA"}`), mergeConfig, io.Discard)
+			processWithConfig(strings.NewReader(`{"Action":"pass","Package":"example.com/b","Test":"TestB"}`), mergeConfig, io.Discard)
+
+			data, _ := os.ReadFile(getTestFilePath(tempDir))
+			for _, name := range []string{"example.com/a/TestA", "example.com/b/TestB"} {
+				if !bytes.Contains(data, []byte(name)) {
+					t.Errorf("Expected %s in merged results, got: %s", name, data)
+				}
+			}
+		})
+
+		t.Run("marks tests with the run they came from", func(t *testing.T) {
+			clearResults(t, tempDir)
+			processWithConfig(strings.NewReader(`{"Action":"pass","Package":"example.com/a","Test":"TestA"}`), mergeConfig, io.Discard)
+
+			data, _ := os.ReadFile(getTestFilePath(tempDir))
+			if !bytes.Contains(data, []byte(`"runId"`)) || !bytes.Contains(data, []byte(`"runs"`)) {
+				t.Fatalf("Expected run markers in merged results, got: %s", data)
+			}
+		})
+
+		t.Run("replaces results without merge mode", func(t *testing.T) {
+			clearResults(t, tempDir)
+			processWithConfig(strings.NewReader(`{"Action":"pass","Package":"example.com/a","Test":"TestA"}`), mergeConfig, io.Discard)
+			data := processAndReadOutput(t, `{"Action":"pass","Package":"example.com/b","Test":"TestB"}`, tempDir)
+
+			if bytes.Contains(data, []byte("example.com/a")) {
+				t.Fatalf("Expected previous results to be replaced, got: %s", data)
+			}
+		})
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		input := `{"Action":"pass","Package":"example.com/a","Test":"TestA"}
+{"Action":"pass","Package":"example.com/a/mocks","Test":"TestMock"}`
+
+		t.Run("raw format passes input through unchanged", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.Format = config.FormatRaw
+
+			var output bytes.Buffer
+			processWithConfig(strings.NewReader(input), cfg, &output)
+
+			if output.String() != input {
+				t.Errorf("Expected raw input, got:\n%s", output.String())
+			}
+		})
+
+		t.Run("formats output in the configured style", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.Style = "testname"
+
+			var output bytes.Buffer
+			processWithConfig(strings.NewReader(input), cfg, &output)
+
+			if !strings.Contains(output.String(), "✓ example.com/a/TestA") {
+				t.Errorf("Expected a line per test, got:\n%s", output.String())
+			}
+		})
+
+		t.Run("colors terminal output but not saved results", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.Color = "always"
+
+			var output bytes.Buffer
+			processWithConfig(strings.NewReader(`{"Action":"fail","Package":"example.com/a","Test":"TestA"}`), cfg, &output)
+
+			if !strings.Contains(output.String(), "\x1b[31mFAIL") {
+				t.Errorf("Expected colored output, got:\n%q", output.String())
+			}
+			data, _ := os.ReadFile(getTestFilePath(tempDir))
+			if bytes.Contains(data, []byte("\x1b")) || bytes.Contains(data, []byte(`\u001b`)) {
+				t.Errorf("Expected no color codes in results, got: %s", data)
+			}
+		})
+
+		t.Run("excluded packages are left out of results", func(t *testing.T) {
+			clearResults(t, tempDir)
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.ExcludePackages = []string{"**/mocks"}
+
+			processWithConfig(strings.NewReader(input), cfg, io.Discard)
+
+			data, _ := os.ReadFile(getTestFilePath(tempDir))
+			if bytes.Contains(data, []byte("TestMock")) || !bytes.Contains(data, []byte("TestA")) {
+				t.Errorf("Expected only unfiltered packages, got: %s", data)
+			}
+		})
+
+		t.Run("reports how many results were filtered", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.ExcludeTests = []string{"TestMock"}
+
+			var output bytes.Buffer
+			processWithConfig(strings.NewReader(input), cfg, &output)
+
+			if !strings.Contains(output.String(), "tdd-guard-go: filtered 1 test from results") {
+				t.Errorf("Expected filter summary, got:\n%s", output.String())
+			}
+		})
+
+		t.Run("keeps filtered tests as skipped in skip mode", func(t *testing.T) {
+			clearResults(t, tempDir)
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.ExcludePackages = []string{"**/mocks"}
+			cfg.FilterMode = "skip"
+
+			processWithConfig(strings.NewReader(input), cfg, io.Discard)
+
+			data, _ := os.ReadFile(getTestFilePath(tempDir))
+			if !bytes.Contains(data, []byte(`"fullName":"example.com/a/mocks/TestMock","state":"skipped"`)) {
+				t.Errorf("Expected filtered test to be skipped, got: %s", data)
+			}
+		})
+
+		t.Run("saves results in configured data directory", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.DataDir = "results"
+
+			processWithConfig(strings.NewReader(input), cfg, io.Discard)
+
+			if _, err := os.Stat(filepath.Join(tempDir, "results", "test.json")); err != nil {
+				t.Errorf("Expected results in data directory: %v", err)
+			}
+		})
+
+		t.Run("writes JUnit XML when configured", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.JUnitFile = filepath.Join(tempDir, "junit.xml")
+
+			processWithConfig(strings.NewReader(input), cfg, io.Discard)
+
+			data, _ := os.ReadFile(cfg.JUnitFile)
+			if !bytes.Contains(data, []byte(`<testcase name="TestMock" classname="example.com/a/mocks"`)) {
+				t.Errorf("Expected JUnit test case, got: %s", data)
+			}
+		})
+
+		t.Run("writes TAP and Markdown when configured", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.TAPFile = filepath.Join(tempDir, "results.tap")
+			cfg.MarkdownFile = filepath.Join(tempDir, "summary.md")
+
+			processWithConfig(strings.NewReader(input), cfg, io.Discard)
+
+			tap, _ := os.ReadFile(cfg.TAPFile)
+			if !bytes.HasPrefix(tap, []byte("TAP version 14\n")) {
+				t.Errorf("Expected TAP output, got: %s", tap)
+			}
+			markdown, _ := os.ReadFile(cfg.MarkdownFile)
+			if !bytes.Contains(markdown, []byte("Tests passed")) {
+				t.Errorf("Expected Markdown summary, got: %s", markdown)
+			}
+		})
+
+		t.Run("flags slow tests in results", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.SlowThreshold = time.Second
+			slowInput := `{"Action":"pass","Package":"example.com/a","Test":"TestSlow","Elapsed":2}`
+
+			if err := processWithConfig(strings.NewReader(slowInput), cfg, io.Discard); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			data, _ := os.ReadFile(getTestFilePath(tempDir))
+			if !bytes.Contains(data, []byte(`"state":"passed"`)) || !bytes.Contains(data, []byte(`"slow":true`)) {
+				t.Errorf("Expected passing test flagged as slow, got: %s", data)
+			}
+		})
+
+		t.Run("fails slow tests and the run in strict mode", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.SlowThresholds = []string{"example.com/a=1s"}
+			cfg.SlowStrict = true
+			slowInput := `{"Action":"pass","Package":"example.com/a","Test":"TestSlow","Elapsed":2}`
+
+			err := processWithConfig(strings.NewReader(slowInput), cfg, io.Discard)
+
+			if err == nil || !strings.Contains(err.Error(), "1 slow test exceeded") {
+				t.Errorf("Expected slow test error, got: %v", err)
+			}
+			data, _ := os.ReadFile(getTestFilePath(tempDir))
+			if !bytes.Contains(data, []byte(`"state":"failed"`)) {
+				t.Errorf("Expected slow test to fail, got: %s", data)
+			}
+		})
+
+		t.Run("fails packages over their budget in strict mode", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.SlowBudgets = []string{"example.com/a=1s"}
+			cfg.SlowStrict = true
+			slowInput := strings.Join([]string{
+				`{"Action":"pass","Package":"example.com/a","Test":"TestFast","Elapsed":0.1}`,
+				`{"Action":"pass","Package":"example.com/a","Elapsed":2}`,
+			}, "\n")
+
+			err := processWithConfig(strings.NewReader(slowInput), cfg, io.Discard)
+
+			if err == nil || !strings.Contains(err.Error(), "1 package exceeded its slow budget") {
+				t.Errorf("Expected slow package error, got: %v", err)
+			}
+		})
+
+		t.Run("attributes events without a package to the test binary's package", func(t *testing.T) {
+			projectDir := t.TempDir()
+			os.MkdirAll(filepath.Join(projectDir, "calc"), 0755)
+			os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/app\n"), 0644)
+			os.WriteFile(filepath.Join(projectDir, "calc", "calc_test.go"), []byte("package calc\n"), 0644)
+			os.Chdir(projectDir)
+			defer os.Chdir(tempDir)
+
+			cfg := config.Default()
+			cfg.ProjectRoot = projectDir
+			cfg.Package = "./calc.test"
+			input := `{"Action":"pass","Test":"TestAdd"}` + "\n" + `{"Action":"pass","Elapsed":0.01}`
+			if err := processWithConfig(strings.NewReader(input), cfg, io.Discard); err != nil {
+				t.Fatal(err)
+			}
+
+			data, _ := os.ReadFile(getTestFilePath(projectDir))
+			if !bytes.Contains(data, []byte(`"fullName":"example.com/app/calc/TestAdd"`)) {
+				t.Errorf("Expected test of example.com/app/calc, got: %s", data)
+			}
+		})
+
+		t.Run("rejects test binaries of unknown packages", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.Package = "./missing.test"
+
+			err := processWithConfig(strings.NewReader(""), cfg, io.Discard)
+			assertErrorContains(t, err, "cannot find the package of test binary ./missing.test")
+		})
+
+		t.Run("verbose output reports results path", func(t *testing.T) {
+			cfg := config.Default()
+			cfg.ProjectRoot = tempDir
+			cfg.Verbosity = config.VerbosityVerbose
+
+			var output bytes.Buffer
+			processWithConfig(strings.NewReader(input), cfg, &output)
+
+			if !strings.Contains(output.String(), "saved test results to "+getTestFilePath(tempDir)) {
+				t.Errorf("Expected results path in output, got:\n%s", output.String())
+			}
+		})
+	})
+
+	t.Run("plain text output", func(t *testing.T) {
+		t.Run("reads results of go test without -json", func(t *testing.T) {
+			input := strings.Join([]string{
+				"=== RUN   TestAdd",
+				"--- PASS: TestAdd (0.00s)",
+				"=== RUN   TestDivide",
+				"    calc_test.go:12: division by zero",
+				"--- FAIL: TestDivide (0.00s)",
+				"FAIL",
+				"FAIL\texample.com/calc\t0.010s",
+			}, "\n")
+			data := processAndReadOutput(t, input, tempDir)
+
+			for _, expected := range []string{`"name":"TestAdd","fullName":"example.com/calc/TestAdd","state":"passed"`, `"message":"calc_test.go:12: division by zero"`, `"reason":"failed"`} {
+				if !bytes.Contains(data, []byte(expected)) {
+					t.Errorf("Expected %s in results, got: %s", expected, data)
+				}
+			}
+		})
+	})
+
 	t.Run("compilation error handling", func(t *testing.T) {
 		t.Run("handles JSON-only build failure correctly", func(t *testing.T) {
 			// This simulates a build failure that produces JSON output
@@ -259,8 +728,8 @@ func assertFileExists(t *testing.T, projectRoot string) {
 
 func assertErrorContains(t *testing.T, err error, expected string) {
 	t.Helper()
-	if err == nil || err.Error() != expected {
-		t.Fatalf("Expected error '%s', got: %v", expected, err)
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Expected error containing '%s', got: %v", expected, err)
 	}
 }
 
@@ -269,6 +738,11 @@ func getTestFilePath(projectRoot string) string {
 	return filepath.Join(parts...)
 }
 
+func clearResults(t *testing.T, projectRoot string) {
+	t.Helper()
+	os.Remove(getTestFilePath(projectRoot))
+}
+
 func processAndReadOutput(t *testing.T, input string, projectRoot string) []byte {
 	t.Helper()
 	err := process(bytes.NewReader([]byte(input)), projectRoot, io.Discard)
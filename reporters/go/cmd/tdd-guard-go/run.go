@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
+	"github.com/nizos/tdd-guard/reporters/go/internal/resolver"
+	"github.com/nizos/tdd-guard/reporters/go/internal/runner"
+)
+
+// goCommand is the go tool the run command launches
+var goCommand = "go"
+
+// runSetup holds what the commands launching go test need
+type runSetup struct {
+	cfg     *config.Config
+	goArgs  []string
+	root    string
+	modules []resolver.Module
+}
+
+// runTests implements the run subcommand: it runs go test in every module of
+// the project and saves the combined results
+func runTests(args []string, stdout, stderr io.Writer) int {
+	setup, err := loadRunSetup("tdd-guard-go run", args, stderr)
+	if err != nil {
+		return reportError(err, stderr)
+	}
+	if len(setup.goArgs) == 0 {
+		setup.goArgs = []string{"./..."}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	results := newRunner(setup.cfg).Run(ctx, setup.modules, setup.goArgs)
+
+	if err := processWithConfig(bytes.NewReader(runner.Combine(results)), setup.cfg, stdout); err != nil {
+		return reportError(err, stderr)
+	}
+	if runner.Failed(results) {
+		return 1
+	}
+	return 0
+}
+
+// loadRunSetup loads the configuration and finds the project's modules.
+// Arguments left after the reporter flags are passed to go test.
+func loadRunSetup(name string, args []string, stderr io.Writer) (*runSetup, error) {
+	cfg, goArgs, err := config.Load(name, args, stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	root := cfg.ProjectRoot
+	if root == "" {
+		if root, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+	}
+
+	modules := resolver.FindModules(root)
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no Go modules found in %s", root)
+	}
+	return &runSetup{cfg: cfg, goArgs: goArgs, root: root, modules: modules}, nil
+}
+
+// newRunner creates a runner testing the configured number of modules in parallel
+func newRunner(cfg *config.Config) *runner.Runner {
+	jobs := cfg.Jobs
+	if jobs == 0 {
+		jobs = runtime.NumCPU()
+	}
+	r := runner.NewRunner(goCommand)
+	r.SetJobs(jobs)
+	return r
+}
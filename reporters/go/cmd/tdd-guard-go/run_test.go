@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunTests(t *testing.T) {
+	t.Setenv("CLAUDE_PROJECT_DIR", "")
+	t.Setenv("GOFLAGS", "")
+	root, _ := filepath.EvalSymlinks(t.TempDir())
+	writeModule(t, root, "alpha", "example.com/alpha", "func TestAlpha(t *testing.T) {}")
+	writeModule(t, root, "beta", "example.com/beta", `func TestBeta(t *testing.T) { t.Fatal("boom") }`)
+	os.WriteFile(filepath.Join(root, "go.work"), []byte("go 1.24\n\nuse (\n\t./alpha\n\t./beta\n)\n"), 0644)
+	t.Chdir(root)
+
+	t.Run("combines results of every workspace module", func(t *testing.T) {
+		code := run([]string{"run", "-jobs", "2"}, strings.NewReader(""), io.Discard, io.Discard)
+
+		if code != 1 {
+			t.Errorf("Expected exit code 1 for failing module, got %d", code)
+		}
+		data, _ := os.ReadFile(getTestFilePath(root))
+		for _, expected := range []string{
+			`"fullName":"example.com/alpha/TestAlpha","state":"passed"`,
+			`"fullName":"example.com/beta/TestBeta","state":"failed"`,
+		} {
+			if !bytes.Contains(data, []byte(expected)) {
+				t.Errorf("Expected %s in results, got: %s", expected, data)
+			}
+		}
+	})
+
+	t.Run("passes arguments to go test", func(t *testing.T) {
+		code := run([]string{"run", "--", "-run", "TestAlpha", "./..."}, strings.NewReader(""), io.Discard, io.Discard)
+
+		if code != 0 {
+			t.Errorf("Expected exit code 0, got %d", code)
+		}
+		data, _ := os.ReadFile(getTestFilePath(root))
+		if bytes.Contains(data, []byte(`"name":"TestBeta"`)) {
+			t.Errorf("Expected only selected tests in results, got: %s", data)
+		}
+	})
+
+	t.Run("reports modules that fail without running tests", func(t *testing.T) {
+		brokenRoot, _ := filepath.EvalSymlinks(t.TempDir())
+		writeModule(t, brokenRoot, "alpha", "example.com/alpha", "func TestAlpha(t *testing.T) {}")
+		os.MkdirAll(filepath.Join(brokenRoot, "broken"), 0755)
+		os.WriteFile(filepath.Join(brokenRoot, "broken", "go.mod"), []byte("module example.com/broken\n\nrequire (\n"), 0644)
+		t.Chdir(brokenRoot)
+		defer t.Chdir(root)
+
+		code := run([]string{"run", "-project-root", brokenRoot}, strings.NewReader(""), io.Discard, io.Discard)
+
+		if code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+		data, _ := os.ReadFile(getTestFilePath(brokenRoot))
+		for _, expected := range []string{
+			`"fullName":"example.com/alpha/TestAlpha","state":"passed"`,
+			`"fullName":"example.com/broken/ModuleError","state":"failed"`,
+		} {
+			if !bytes.Contains(data, []byte(expected)) {
+				t.Errorf("Expected %s in results, got: %s", expected, data)
+			}
+		}
+	})
+
+	t.Run("fails without modules", func(t *testing.T) {
+		emptyRoot, _ := filepath.EvalSymlinks(t.TempDir())
+		t.Chdir(emptyRoot)
+		defer t.Chdir(root)
+
+		var stderr bytes.Buffer
+		code := run([]string{"run", "-project-root", emptyRoot}, strings.NewReader(""), io.Discard, &stderr)
+
+		if code != 1 || !strings.Contains(stderr.String(), "no Go modules found") {
+			t.Errorf("Expected missing modules error, got code %d: %s", code, stderr.String())
+		}
+	})
+}
+
+// Helper functions
+
+func writeModule(t *testing.T, root, dir, path, test string) {
+	t.Helper()
+	moduleDir := filepath.Join(root, dir)
+	os.MkdirAll(moduleDir, 0755)
+	os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module "+path+"\n\ngo 1.24\n"), 0644)
+	source := "package " + dir + "\n\nimport \"testing\"\n\n" + test + "\n"
+	if err := os.WriteFile(filepath.Join(moduleDir, dir+"_test.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/affected"
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
+	"github.com/nizos/tdd-guard/reporters/go/internal/runner"
+	"github.com/nizos/tdd-guard/reporters/go/internal/watcher"
+)
+
+// runWatch implements the watch subcommand: it reruns the packages affected
+// by each batch of file changes until interrupted
+func runWatch(args []string, stdout, stderr io.Writer) int {
+	setup, err := loadRunSetup("tdd-guard-go watch", args, stderr)
+	if err != nil {
+		return reportError(err, stderr)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	changes := make(chan string)
+	watchErr := make(chan error, 1)
+	go func() {
+		defer close(changes)
+		watchErr <- watcher.Watch(ctx, setup.root, changes)
+	}()
+
+	if setup.cfg.Verbosity != config.VerbosityQuiet {
+		fmt.Fprintf(stdout, "tdd-guard-go: watching %s for changes\n", setup.root)
+	}
+	watchLoop(ctx, setup, watcher.Debounce(ctx, changes, setup.cfg.Debounce), stdout, stderr)
+
+	if err := <-watchErr; err != nil {
+		return reportError(err, stderr)
+	}
+	return 0
+}
+
+// watchLoop tests the packages affected by each batch of changed files.
+// A batch arriving while tests are running cancels them, and the files of
+// the cancelled run are tested together with the new batch. Returns once
+// batches is closed and the last run finished.
+func watchLoop(ctx context.Context, setup *runSetup, batches <-chan []string, stdout, stderr io.Writer) {
+	var current *activeRun
+
+	for batch := range batches {
+		if current != nil {
+			current.cancel()
+			if !<-current.completed {
+				batch = append(current.files, batch...)
+			}
+		}
+		current = startRun(ctx, setup, batch, stdout, stderr)
+	}
+	if current != nil {
+		<-current.completed
+		current.cancel()
+	}
+}
+
+// activeRun is a watch run in progress
+type activeRun struct {
+	files     []string
+	cancel    context.CancelFunc
+	completed chan bool
+}
+
+// startRun starts testing the changed files in the background
+func startRun(ctx context.Context, setup *runSetup, files []string, stdout, stderr io.Writer) *activeRun {
+	runCtx, cancel := context.WithCancel(ctx)
+	r := &activeRun{files: files, cancel: cancel, completed: make(chan bool, 1)}
+	go func() {
+		r.completed <- watchRun(runCtx, setup, files, stdout, stderr)
+	}()
+	return r
+}
+
+// watchRun tests the packages affected by the changed files and saves the
+// results. Returns false when the run was cancelled before finishing.
+func watchRun(ctx context.Context, setup *runSetup, files []string, stdout, stderr io.Writer) bool {
+	graph := affected.NewGraph(setup.modules)
+	if err := graph.Load(ctx, goCommand); err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		fmt.Fprintf(stderr, "tdd-guard-go: load import graph: %v\n", err)
+		return true
+	}
+
+	jobs, notRun := affectedJobs(setup, graph.Select(files))
+	if len(jobs) == 0 {
+		if setup.cfg.Verbosity != config.VerbosityQuiet {
+			fmt.Fprintln(stdout, "tdd-guard-go: no packages affected by changes")
+		}
+		return true
+	}
+
+	results := newRunner(setup.cfg).RunJobs(ctx, jobs)
+	if ctx.Err() != nil {
+		return false
+	}
+	if err := processRun(bytes.NewReader(runner.Combine(results)), setup.cfg, stdout, notRun); err != nil {
+		fmt.Fprintf(stderr, "tdd-guard-go: %v\n", err)
+	}
+	return true
+}
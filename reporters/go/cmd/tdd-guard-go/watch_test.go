@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWatchLoop(t *testing.T) {
+	t.Setenv("CLAUDE_PROJECT_DIR", "")
+	t.Setenv("GOFLAGS", "")
+	root, _ := filepath.EvalSymlinks(t.TempDir())
+	writeModule(t, root, "alpha", "example.com/alpha", "func TestAlpha(t *testing.T) {}")
+	writeModule(t, root, "beta", "example.com/beta", "func TestBeta(t *testing.T) {}")
+	os.WriteFile(filepath.Join(root, "go.work"), []byte("go 1.24\n\nuse (\n\t./alpha\n\t./beta\n)\n"), 0644)
+	t.Chdir(root)
+
+	setup, err := loadRunSetup("tdd-guard-go watch", nil, io.Discard)
+	if err != nil {
+		t.Fatalf("loadRunSetup failed: %v", err)
+	}
+	alphaFile := filepath.Join(root, "alpha", "alpha_test.go")
+	betaFile := filepath.Join(root, "beta", "beta_test.go")
+
+	t.Run("tests packages affected by each batch", func(t *testing.T) {
+		data := watchBatches(t, setup, []string{betaFile})
+
+		if !bytes.Contains(data, []byte(`"name":"TestBeta"`)) {
+			t.Errorf("Expected TestBeta in results, got: %s", data)
+		}
+		if bytes.Contains(data, []byte(`"name":"TestAlpha"`)) {
+			t.Errorf("Expected unaffected package not to run, got: %s", data)
+		}
+	})
+
+	t.Run("retests files of a cancelled run with the next batch", func(t *testing.T) {
+		data := watchBatches(t, setup, []string{alphaFile}, []string{betaFile})
+
+		for _, expected := range []string{`"name":"TestAlpha"`, `"name":"TestBeta"`} {
+			if !bytes.Contains(data, []byte(expected)) {
+				t.Errorf("Expected %s in results, got: %s", expected, data)
+			}
+		}
+	})
+
+	t.Run("reports batches without affected packages", func(t *testing.T) {
+		var stdout bytes.Buffer
+		batches := make(chan []string, 1)
+		batches <- []string{filepath.Join(root, "README.md")}
+		close(batches)
+
+		watchLoop(context.Background(), setup, batches, &stdout, io.Discard)
+
+		if !strings.Contains(stdout.String(), "no packages affected") {
+			t.Errorf("Expected no affected packages message, got: %s", stdout.String())
+		}
+	})
+}
+
+// Helper functions
+
+func watchBatches(t *testing.T, setup *runSetup, batches ...[]string) []byte {
+	t.Helper()
+	os.Remove(getTestFilePath(setup.root))
+	ch := make(chan []string, len(batches))
+	for _, batch := range batches {
+		ch <- batch
+	}
+	close(ch)
+
+	watchLoop(context.Background(), setup, ch, io.Discard, io.Discard)
+
+	data, _ := os.ReadFile(getTestFilePath(setup.root))
+	return data
+}
@@ -0,0 +1,274 @@
+package affected
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/resolver"
+)
+
+// listedPackage holds the go list -json fields needed for the import graph
+type listedPackage struct {
+	ImportPath string
+	Dir        string
+	Name       string
+	ForTest    string
+	Standard   bool
+	Module     *struct {
+		Path string
+	}
+	Imports []string
+}
+
+// pkg is a package of one of the project's modules
+type pkg struct {
+	importPath string
+	dir        string
+	module     string
+	importedBy map[string]bool
+}
+
+// Graph is the reverse import graph of the project's packages, including
+// imports of their tests
+type Graph struct {
+	packages map[string]*pkg
+	modules  []resolver.Module
+}
+
+// Selection is the outcome of selecting affected packages in one module
+type Selection struct {
+	Module   resolver.Module
+	Affected []string
+	NotRun   []string
+}
+
+// NewGraph creates an empty graph for the modules
+func NewGraph(modules []resolver.Module) *Graph {
+	return &Graph{packages: make(map[string]*pkg), modules: modules}
+}
+
+// Load adds the packages of every module by running
+// go list -deps -test -json in each module directory
+func (g *Graph) Load(ctx context.Context, goBinary string) error {
+	for _, m := range g.modules {
+		cmd := exec.CommandContext(ctx, goBinary, "list", "-e", "-deps", "-test", "-json", "./...")
+		cmd.Dir = m.Dir
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		output, err := cmd.Output()
+		if err != nil {
+			return fmt.Errorf("go list in %s: %w: %s", m.Dir, err, strings.TrimSpace(stderr.String()))
+		}
+		if err := g.Add(bytes.NewReader(output)); err != nil {
+			return fmt.Errorf("go list in %s: %w", m.Dir, err)
+		}
+	}
+	return nil
+}
+
+// Add reads a stream of go list -json packages into the graph.
+// Test variants are folded into the package they test, so a package is
+// affected by anything its tests import.
+func (g *Graph) Add(reader io.Reader) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		var listed listedPackage
+		if err := decoder.Decode(&listed); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if listed.Standard || listed.Module == nil || !g.isProjectModule(listed.Module.Path) {
+			continue
+		}
+		// The generated test main package only imports the tests themselves
+		if listed.Name == "main" && strings.HasSuffix(listed.ImportPath, ".test") {
+			continue
+		}
+
+		importPath := stripVariant(listed.ImportPath)
+		if listed.ForTest != "" {
+			importPath = listed.ForTest
+		}
+		p := g.pkg(importPath)
+		if listed.ForTest == "" || p.dir == "" {
+			p.dir = listed.Dir
+			p.module = listed.Module.Path
+		}
+
+		for _, imported := range listed.Imports {
+			imported = stripVariant(imported)
+			if imported != importPath {
+				g.pkg(imported).importedBy[importPath] = true
+			}
+		}
+	}
+}
+
+// Select returns, for every module, the packages that contain a changed file
+// or transitively import such a package, and the packages that are not
+// affected. A changed go.mod or go.sum affects every package of its module
+// and a changed testdata file affects the package owning the testdata.
+func (g *Graph) Select(changedFiles []string) []Selection {
+	affected := make(map[string]bool)
+	var queue []string
+	mark := func(importPath string) {
+		if !affected[importPath] {
+			affected[importPath] = true
+			queue = append(queue, importPath)
+		}
+	}
+
+	for _, file := range changedFiles {
+		dir := filepath.Dir(file)
+		switch filepath.Base(file) {
+		case "go.mod", "go.sum":
+			for _, p := range g.packages {
+				if p.dir != "" && g.moduleDir(p.module) == dir {
+					mark(p.importPath)
+				}
+			}
+			continue
+		}
+		if owner, ok := testdataOwner(file); ok {
+			dir = owner
+		} else if filepath.Ext(file) != ".go" {
+			continue
+		}
+		for _, p := range g.packages {
+			if p.dir == dir {
+				mark(p.importPath)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		importPath := queue[0]
+		queue = queue[1:]
+		for importer := range g.packages[importPath].importedBy {
+			mark(importer)
+		}
+	}
+
+	return g.selections(affected)
+}
+
+// testdataOwner finds the package directory holding the testdata
+// directory a file belongs to
+func testdataOwner(file string) (string, bool) {
+	owner := ""
+	for dir := filepath.Dir(file); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == "testdata" {
+			owner = filepath.Dir(dir)
+		}
+	}
+	return owner, owner != ""
+}
+
+// selections groups packages by module into affected and not run
+func (g *Graph) selections(affected map[string]bool) []Selection {
+	selections := make([]Selection, 0, len(g.modules))
+	for _, m := range g.modules {
+		selection := Selection{Module: m}
+		for _, p := range g.packages {
+			if p.module != m.Path || p.dir == "" {
+				continue
+			}
+			if affected[p.importPath] {
+				selection.Affected = append(selection.Affected, p.importPath)
+			} else {
+				selection.NotRun = append(selection.NotRun, p.importPath)
+			}
+		}
+		sort.Strings(selection.Affected)
+		sort.Strings(selection.NotRun)
+		selections = append(selections, selection)
+	}
+	return selections
+}
+
+// pkg returns the graph node for the import path, creating it if needed
+func (g *Graph) pkg(importPath string) *pkg {
+	p, exists := g.packages[importPath]
+	if !exists {
+		p = &pkg{importPath: importPath, importedBy: make(map[string]bool)}
+		g.packages[importPath] = p
+	}
+	return p
+}
+
+func (g *Graph) isProjectModule(path string) bool {
+	return g.moduleDir(path) != ""
+}
+
+func (g *Graph) moduleDir(path string) string {
+	for _, m := range g.modules {
+		if m.Path == path {
+			return m.Dir
+		}
+	}
+	return ""
+}
+
+// ChangedFiles lists files changed in the git working tree of dir compared
+// with HEAD, including staged and untracked files, as absolute paths
+func ChangedFiles(ctx context.Context, dir string) ([]string, error) {
+	top, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(top)
+
+	changed, err := git(ctx, dir, "diff", "--name-only", "--no-renames", "HEAD", "--")
+	if err != nil {
+		// A repository without commits has nothing to compare with
+		changed, err = git(ctx, dir, "ls-files", "--full-name")
+		if err != nil {
+			return nil, err
+		}
+	}
+	untracked, err := git(ctx, dir, "ls-files", "--others", "--exclude-standard", "--full-name")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(changed+untracked, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, filepath.Join(root, filepath.FromSlash(line)))
+		}
+	}
+	return files, nil
+}
+
+// git runs a git command in dir and returns its output
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
+
+// stripVariant removes the " [pkg.test]" suffix of test variant import paths
+func stripVariant(importPath string) string {
+	if i := strings.Index(importPath, " ["); i >= 0 {
+		return importPath[:i]
+	}
+	return importPath
+}
@@ -0,0 +1,149 @@
+package affected
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/resolver"
+)
+
+func TestGraph(t *testing.T) {
+	app := resolver.Module{Path: "example.com/app", Dir: filepath.Join("/", "src", "app")}
+	lib := resolver.Module{Path: "example.com/lib", Dir: filepath.Join("/", "src", "lib")}
+
+	graph := NewGraph([]resolver.Module{app, lib})
+	err := graph.Add(strings.NewReader(`
+{"ImportPath": "fmt", "Standard": true}
+{"ImportPath": "example.com/lib/util", "Dir": "/src/lib/util", "Name": "util", "Module": {"Path": "example.com/lib"}, "Imports": ["fmt"]}
+{"ImportPath": "example.com/app/core", "Dir": "/src/app/core", "Name": "core", "Module": {"Path": "example.com/app"}, "Imports": ["example.com/lib/util"]}
+{"ImportPath": "example.com/app/api", "Dir": "/src/app/api", "Name": "api", "Module": {"Path": "example.com/app"}, "Imports": ["example.com/app/core"]}
+{"ImportPath": "example.com/app/cli", "Dir": "/src/app/cli", "Name": "cli", "Module": {"Path": "example.com/app"}}
+{"ImportPath": "example.com/app/cli [example.com/app/cli.test]", "Dir": "/src/app/cli", "Name": "cli", "ForTest": "example.com/app/cli", "Module": {"Path": "example.com/app"}, "Imports": ["example.com/app/testutil"]}
+{"ImportPath": "example.com/app/cli_test [example.com/app/cli.test]", "Dir": "/src/app/cli", "Name": "cli_test", "ForTest": "example.com/app/cli", "Module": {"Path": "example.com/app"}, "Imports": ["example.com/app/cli [example.com/app/cli.test]", "example.com/app/api"]}
+{"ImportPath": "example.com/app/cli.test", "Dir": "/src/app/cli", "Name": "main", "Module": {"Path": "example.com/app"}, "Imports": ["example.com/app/cli_test [example.com/app/cli.test]"]}
+{"ImportPath": "example.com/app/testutil", "Dir": "/src/app/testutil", "Name": "testutil", "Module": {"Path": "example.com/app"}}
+{"ImportPath": "example.com/other/dep", "Dir": "/mod/other/dep", "Name": "dep", "Module": {"Path": "example.com/other"}}
+`))
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	t.Run("selects package containing the changed file", func(t *testing.T) {
+		selections := graph.Select([]string{"/src/app/testutil/helpers.go"})
+		assertAffected(t, selections, "example.com/app/cli", "example.com/app/testutil")
+	})
+
+	t.Run("selects packages transitively importing the changed package", func(t *testing.T) {
+		selections := graph.Select([]string{"/src/lib/util/util.go"})
+		assertAffected(t, selections,
+			"example.com/app/api", "example.com/app/cli", "example.com/app/core", "example.com/lib/util")
+	})
+
+	t.Run("selects packages whose tests import the changed package", func(t *testing.T) {
+		selections := graph.Select([]string{"/src/app/api/api.go"})
+		assertAffected(t, selections, "example.com/app/api", "example.com/app/cli")
+	})
+
+	t.Run("ignores changed files that are not Go source", func(t *testing.T) {
+		assertAffected(t, graph.Select([]string{"/src/app/api/README.md"}))
+	})
+
+	t.Run("selects package owning a changed testdata file", func(t *testing.T) {
+		selections := graph.Select([]string{"/src/app/core/testdata/golden/output.txt"})
+		assertAffected(t, selections, "example.com/app/api", "example.com/app/cli", "example.com/app/core")
+	})
+
+	t.Run("selects every package of a module with a changed go.mod", func(t *testing.T) {
+		selections := graph.Select([]string{"/src/app/go.mod"})
+		assertAffected(t, selections,
+			"example.com/app/api", "example.com/app/cli", "example.com/app/core", "example.com/app/testutil")
+	})
+
+	t.Run("lists unaffected packages of each module as not run", func(t *testing.T) {
+		selections := graph.Select([]string{"/src/app/api/api.go"})
+		expected := []string{"example.com/app/core", "example.com/app/testutil"}
+		if !reflect.DeepEqual(selections[0].NotRun, expected) {
+			t.Errorf("Expected %v not run, got %v", expected, selections[0].NotRun)
+		}
+		if !reflect.DeepEqual(selections[1].NotRun, []string{"example.com/lib/util"}) {
+			t.Errorf("Expected lib package not run, got %v", selections[1].NotRun)
+		}
+	})
+
+	t.Run("ignores packages outside the project modules", func(t *testing.T) {
+		for _, selection := range graph.Select(nil) {
+			for _, pkg := range selection.NotRun {
+				if strings.HasPrefix(pkg, "example.com/other") || pkg == "fmt" {
+					t.Errorf("Expected only project packages, got %s", pkg)
+				}
+			}
+		}
+	})
+}
+
+func TestChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root, _ := filepath.EvalSymlinks(t.TempDir())
+	gitRun(t, root, "init", "-q")
+	writeFile(t, root, "committed.go", "package app\n")
+	writeFile(t, root, "unchanged.go", "package app\n")
+	gitRun(t, root, "add", ".")
+	gitRun(t, root, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+
+	writeFile(t, root, "committed.go", "package app\n\n// changed\n")
+	writeFile(t, root, "sub/new.go", "package sub\n")
+
+	files, err := ChangedFiles(context.Background(), filepath.Join(root, "sub"))
+	if err != nil {
+		t.Fatalf("ChangedFiles failed: %v", err)
+	}
+
+	sort.Strings(files)
+	expected := []string{filepath.Join(root, "committed.go"), filepath.Join(root, "sub", "new.go")}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+}
+
+// Helper functions
+
+func assertAffected(t *testing.T, selections []Selection, expected ...string) {
+	t.Helper()
+	var got []string
+	for _, selection := range selections {
+		got = append(got, selection.Affected...)
+	}
+	sort.Strings(got)
+	if len(got) == 0 && len(expected) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected affected %v, got %v", expected, got)
+	}
+}
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	os.MkdirAll(filepath.Dir(path), 0755)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// StaleFiles lists the non-Go files of a package directory, including its
+// testdata tree, that were modified after since. Paths are relative to dir
+// and use forward slashes. Subdirectories other than testdata are other
+// packages and are left out.
+func StaleFiles(dir string, since time.Time) ([]string, error) {
+	var stale []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if path != dir && rel != "testdata" && !strings.HasPrefix(rel, "testdata/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".go") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(since) {
+			stale = append(stale, rel)
+		}
+		return nil
+	})
+	sort.Strings(stale)
+	return stale, err
+}
+
+// Warn returns a warning for every cached module whose package has non-Go
+// files modified after since. Go reuses cached results as long as the Go
+// sources are unchanged, so these results may predate the edit. Packages
+// the locator cannot find in the project under root are skipped.
+func Warn(result *transformer.TestResult, root string, locator transformer.Locator, since time.Time) []string {
+	var warnings []string
+	for _, module := range result.TestModules {
+		if !module.Cached {
+			continue
+		}
+		dir, ok := locator.PackageDir(module.Package)
+		if !ok {
+			continue
+		}
+		stale, err := StaleFiles(filepath.Join(root, filepath.FromSlash(dir)), since)
+		if err != nil || len(stale) == 0 {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: cached result may be stale, %s changed since the last run",
+			module.Package, strings.Join(stale, ", ")))
+	}
+	return warnings
+}
+
+// LastModified returns when the file at path was last written
+func LastModified(path string) (time.Time, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
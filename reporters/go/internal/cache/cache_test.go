@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	lastRun := time.Now().Add(-time.Hour)
+	writeFile(t, dir, "calc.go", lastRun.Add(time.Minute))
+	writeFile(t, dir, "config.yaml", lastRun.Add(-time.Minute))
+	writeFile(t, dir, "testdata/input.txt", lastRun.Add(time.Minute))
+	writeFile(t, dir, "testdata/golden/output.txt", lastRun.Add(time.Minute))
+	writeFile(t, dir, "sub/data.txt", lastRun.Add(time.Minute))
+
+	stale, err := StaleFiles(dir, lastRun)
+	if err != nil {
+		t.Fatalf("StaleFiles failed: %v", err)
+	}
+
+	t.Run("lists non-Go files modified since the last run", func(t *testing.T) {
+		expected := []string{"testdata/golden/output.txt", "testdata/input.txt"}
+		if !reflect.DeepEqual(stale, expected) {
+			t.Errorf("Expected %v, got %v", expected, stale)
+		}
+	})
+}
+
+func TestWarn(t *testing.T) {
+	root := t.TempDir()
+	lastRun := time.Now().Add(-time.Hour)
+	writeFile(t, root, "calc/testdata/input.txt", lastRun.Add(time.Minute))
+	writeFile(t, root, "fresh/testdata/input.txt", lastRun.Add(time.Minute))
+	locator := fakeLocator{"example.com/calc": "calc", "example.com/fresh": "fresh"}
+	result := &transformer.TestResult{TestModules: []transformer.TestModule{
+		{Package: "example.com/calc", Cached: true},
+		{Package: "example.com/fresh"},
+		{Package: "example.com/unknown", Cached: true},
+	}}
+
+	warnings := Warn(result, root, locator, lastRun)
+
+	t.Run("warns about cached packages with changed files", func(t *testing.T) {
+		if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "example.com/calc: cached result may be stale, testdata/input.txt changed") {
+			t.Errorf("Expected warning for example.com/calc, got %v", warnings)
+		}
+	})
+
+	t.Run("stays quiet when nothing changed", func(t *testing.T) {
+		if warnings := Warn(result, root, locator, time.Now().Add(time.Hour)); len(warnings) != 0 {
+			t.Errorf("Expected no warnings, got %v", warnings)
+		}
+	})
+}
+
+// Helper functions
+
+type fakeLocator map[string]string
+
+func (l fakeLocator) PackageDir(pkg string) (string, bool) {
+	dir, ok := l[pkg]
+	return dir, ok
+}
+
+func (l fakeLocator) TestLocation(pkg, test string) (string, int, bool) {
+	return "", 0, false
+}
+
+func writeFile(t *testing.T, dir, name string, modified time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,616 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/filter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/project"
+	"github.com/nizos/tdd-guard/reporters/go/internal/storage"
+	"github.com/nizos/tdd-guard/reporters/go/internal/style"
+)
+
+var (
+	// Path components of the JSON config file, relative to the project root
+	JSONConfigPath = []string{".claude", "tdd-guard", "go-reporter.json"}
+
+	// TOMLConfigFile is looked up at the module root
+	TOMLConfigFile = "tdd-guard.toml"
+)
+
+// TOMLSection holds the reporter settings inside TOMLConfigFile
+const TOMLSection = "tool.tdd-guard-go"
+
+// envPrefix is prepended to a setting's name to form its environment variable
+const envPrefix = "TDD_GUARD_GO_"
+
+// Output formats and verbosity levels
+const (
+	FormatStandard = "standard"
+	FormatRaw      = "raw"
+
+	VerbosityQuiet   = "quiet"
+	VerbosityNormal  = "normal"
+	VerbosityVerbose = "verbose"
+)
+
+// Sources describe where a setting's value came from
+const (
+	SourceDefault = "default"
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+)
+
+// ErrUsage is returned when the command line flags cannot be parsed
+var ErrUsage = errors.New("invalid usage")
+
+// Config holds the effective reporter settings
+type Config struct {
+	ProjectRoot     string
+	DataDir         string
+	Format          string
+	Style           string
+	Color           string
+	Summary         bool
+	Slowest         int
+	SlowThreshold   time.Duration
+	SlowThresholds  []string
+	SlowBudgets     []string
+	SlowStrict      bool
+	Verbosity       string
+	Merge           bool
+	MaxAge          time.Duration
+	LockTimeout     time.Duration
+	IncludePackages []string
+	ExcludePackages []string
+	IncludeTests    []string
+	ExcludeTests    []string
+	FilterMode      string
+	MaxErrorBytes   int
+	Jobs            int
+	Debounce        time.Duration
+	JUnitFile       string
+	TAPFile         string
+	MarkdownFile    string
+	Package         string
+
+	// ConfigFile is the file settings were read from, if any
+	ConfigFile string
+
+	sources map[string]string
+}
+
+// setting describes one configurable value and how to parse it
+type setting struct {
+	name   string
+	usage  string
+	isBool bool
+	set    func(c *Config, value string) error
+	get    func(c *Config) string
+}
+
+var settings = []setting{
+	{
+		name:  "data-dir",
+		usage: "Directory test results are saved in (relative paths are resolved from the project root)",
+		set:   func(c *Config, v string) error { c.DataDir = v; return nil },
+		get:   func(c *Config) string { return c.DataDir },
+	},
+	{
+		name:  "format",
+		usage: "Terminal output format: standard or raw",
+		set: func(c *Config, v string) error {
+			return setChoice(&c.Format, v, FormatStandard, FormatRaw)
+		},
+		get: func(c *Config) string { return c.Format },
+	},
+	{
+		name:  "style",
+		usage: "Terminal output style: standard-quiet, standard-verbose, dots, testname or pkgname",
+		set: func(c *Config, v string) error {
+			return setChoice(&c.Style, v, style.Names()...)
+		},
+		get: func(c *Config) string { return c.Style },
+	},
+	{
+		name:  "color",
+		usage: "Color terminal output: auto, always or never",
+		set: func(c *Config, v string) error {
+			return setChoice(&c.Color, v, style.ColorNames()...)
+		},
+		get: func(c *Config) string { return c.Color },
+	},
+	{
+		name:   "summary",
+		usage:  "Print a summary of failures, slowest tests and counts after the test output",
+		isBool: true,
+		set:    func(c *Config, v string) error { return setBool(&c.Summary, v) },
+		get:    func(c *Config) string { return strconv.FormatBool(c.Summary) },
+	},
+	{
+		name:  "slowest",
+		usage: "Number of slowest tests listed in the summary (0 lists none)",
+		set:   func(c *Config, v string) error { return setInt(&c.Slowest, v) },
+		get:   func(c *Config) string { return strconv.Itoa(c.Slowest) },
+	},
+	{
+		name:  "slow-threshold",
+		usage: "Flag tests taking longer than this as slow (0 disables)",
+		set:   func(c *Config, v string) error { return setDuration(&c.SlowThreshold, v) },
+		get:   func(c *Config) string { return c.SlowThreshold.String() },
+	},
+	{
+		name:  "slow-thresholds",
+		usage: "Comma-separated package=duration slow thresholds overriding -slow-threshold",
+		set:   func(c *Config, v string) error { c.SlowThresholds = splitList(v); return nil },
+		get:   func(c *Config) string { return strings.Join(c.SlowThresholds, ",") },
+	},
+	{
+		name:  "slow-budgets",
+		usage: "Comma-separated package=duration budgets for the total time of matching packages",
+		set:   func(c *Config, v string) error { c.SlowBudgets = splitList(v); return nil },
+		get:   func(c *Config) string { return strings.Join(c.SlowBudgets, ",") },
+	},
+	{
+		name:   "slow-strict",
+		usage:  "Fail slow tests, packages over budget and the run instead of only flagging them",
+		isBool: true,
+		set:    func(c *Config, v string) error { return setBool(&c.SlowStrict, v) },
+		get:    func(c *Config) string { return strconv.FormatBool(c.SlowStrict) },
+	},
+	{
+		name:  "verbosity",
+		usage: "Reporter messages: quiet, normal or verbose",
+		set: func(c *Config, v string) error {
+			return setChoice(&c.Verbosity, v, VerbosityQuiet, VerbosityNormal, VerbosityVerbose)
+		},
+		get: func(c *Config) string { return c.Verbosity },
+	},
+	{
+		name:   "merge",
+		usage:  "Merge results into previously saved results instead of replacing them",
+		isBool: true,
+		set:    func(c *Config, v string) error { return setBool(&c.Merge, v) },
+		get:    func(c *Config) string { return strconv.FormatBool(c.Merge) },
+	},
+	{
+		name:  "max-age",
+		usage: "Drop merged results older than this duration (0 keeps them)",
+		set:   func(c *Config, v string) error { return setDuration(&c.MaxAge, v) },
+		get:   func(c *Config) string { return c.MaxAge.String() },
+	},
+	{
+		name:  "lock-timeout",
+		usage: "How long to wait for other writers of the results file",
+		set:   func(c *Config, v string) error { return setDuration(&c.LockTimeout, v) },
+		get:   func(c *Config) string { return c.LockTimeout.String() },
+	},
+	{
+		name:  "include-packages",
+		usage: "Comma-separated package patterns to report (default all)",
+		set:   func(c *Config, v string) error { c.IncludePackages = splitList(v); return nil },
+		get:   func(c *Config) string { return strings.Join(c.IncludePackages, ",") },
+	},
+	{
+		name:  "exclude-packages",
+		usage: "Comma-separated package patterns to leave out of the results",
+		set:   func(c *Config, v string) error { c.ExcludePackages = splitList(v); return nil },
+		get:   func(c *Config) string { return strings.Join(c.ExcludePackages, ",") },
+	},
+	{
+		name:  "include-tests",
+		usage: "Comma-separated test name patterns to report (default all)",
+		set:   func(c *Config, v string) error { c.IncludeTests = splitList(v); return nil },
+		get:   func(c *Config) string { return strings.Join(c.IncludeTests, ",") },
+	},
+	{
+		name:  "exclude-tests",
+		usage: "Comma-separated test name patterns to leave out of the results",
+		set:   func(c *Config, v string) error { c.ExcludeTests = splitList(v); return nil },
+		get:   func(c *Config) string { return strings.Join(c.ExcludeTests, ",") },
+	},
+	{
+		name:  "filter-mode",
+		usage: "What happens to filtered results: exclude or skip",
+		set: func(c *Config, v string) error {
+			return setChoice(&c.FilterMode, v, string(filter.ModeExclude), string(filter.ModeSkip))
+		},
+		get: func(c *Config) string { return c.FilterMode },
+	},
+	{
+		name:  "max-error-bytes",
+		usage: "Truncate each saved error message to this many bytes (0 keeps them whole)",
+		set:   func(c *Config, v string) error { return setInt(&c.MaxErrorBytes, v) },
+		get:   func(c *Config) string { return strconv.Itoa(c.MaxErrorBytes) },
+	},
+	{
+		name:  "jobs",
+		usage: "Modules tested in parallel by the run command (0 uses one per CPU)",
+		set:   func(c *Config, v string) error { return setInt(&c.Jobs, v) },
+		get:   func(c *Config) string { return strconv.Itoa(c.Jobs) },
+	},
+	{
+		name:  "debounce",
+		usage: "How long the watch command waits for changes to settle before testing",
+		set:   func(c *Config, v string) error { return setDuration(&c.Debounce, v) },
+		get:   func(c *Config) string { return c.Debounce.String() },
+	},
+	{
+		name:  "junit-file",
+		usage: "Also write the results of each run as JUnit XML to this file",
+		set:   func(c *Config, v string) error { c.JUnitFile = v; return nil },
+		get:   func(c *Config) string { return c.JUnitFile },
+	},
+	{
+		name:  "tap-file",
+		usage: "Also write the results of each run as TAP version 14 to this file",
+		set:   func(c *Config, v string) error { c.TAPFile = v; return nil },
+		get:   func(c *Config) string { return c.TAPFile },
+	},
+	{
+		name:  "markdown-file",
+		usage: "Append a Markdown summary of each run to this file, e.g. $GITHUB_STEP_SUMMARY",
+		set:   func(c *Config, v string) error { c.MarkdownFile = v; return nil },
+		get:   func(c *Config) string { return c.MarkdownFile },
+	},
+	{
+		name:  "package",
+		usage: "Import path, or test binary such as ./calc.test, of events without a package, like those of go tool test2json without -p",
+		set:   func(c *Config, v string) error { c.Package = v; return nil },
+		get:   func(c *Config) string { return c.Package },
+	},
+}
+
+// Default returns the built-in settings
+func Default() *Config {
+	return &Config{
+		Format:      FormatStandard,
+		Style:       string(style.StandardQuiet),
+		Color:       string(style.ColorAuto),
+		Summary:     true,
+		Slowest:     5,
+		Verbosity:   VerbosityNormal,
+		FilterMode:  string(filter.ModeExclude),
+		MaxAge:      24 * time.Hour,
+		LockTimeout: storage.DefaultLockTimeout,
+		Jobs:        1,
+		Debounce:    300 * time.Millisecond,
+		sources:     map[string]string{},
+	}
+}
+
+// Load builds the effective configuration from defaults, the config file,
+// environment variables and command line flags, later sources overriding
+// earlier ones. Returns the remaining non-flag arguments.
+// Flag errors and usage are printed to output.
+func Load(name string, args []string, output io.Writer) (*Config, []string, error) {
+	c := Default()
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	values := c.registerFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+
+	if err := c.resolveProjectRoot(values["project-root"]); err != nil {
+		return nil, nil, err
+	}
+	if err := c.loadFile(); err != nil {
+		return nil, nil, err
+	}
+	if err := c.loadEnv(); err != nil {
+		return nil, nil, err
+	}
+	if err := c.loadFlags(values); err != nil {
+		return nil, nil, err
+	}
+
+	return c, fs.Args(), nil
+}
+
+// Source reports where the named setting's value came from
+func (c *Config) Source(name string) string {
+	if source, exists := c.sources[name]; exists {
+		return source
+	}
+	return SourceDefault
+}
+
+// ResultsDir returns the directory test results are saved in
+func (c *Config) ResultsDir() string {
+	if c.DataDir == "" {
+		dirParts := storage.TestResultsPath[:len(storage.TestResultsPath)-1]
+		return filepath.Join(append([]string{c.ProjectRoot}, dirParts...)...)
+	}
+	if filepath.IsAbs(c.DataDir) {
+		return c.DataDir
+	}
+	return filepath.Join(c.ProjectRoot, c.DataDir)
+}
+
+// Show prints every setting with its effective value and source
+func (c *Config) Show(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SETTING\tVALUE\tSOURCE")
+	fmt.Fprintf(tw, "project-root\t%s\t%s\n", c.ProjectRoot, c.Source("project-root"))
+	for _, s := range settings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.name, s.get(c), c.Source(s.name))
+	}
+	return tw.Flush()
+}
+
+// flagValue records a flag's raw value so it can be applied after lower
+// precedence sources have been loaded
+type flagValue struct {
+	value  string
+	isSet  bool
+	isBool bool
+}
+
+func (f *flagValue) String() string { return f.value }
+
+func (f *flagValue) Set(value string) error {
+	f.value = value
+	f.isSet = true
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool { return f.isBool }
+
+// registerFlags defines a flag for every setting
+func (c *Config) registerFlags(fs *flag.FlagSet) map[string]*flagValue {
+	values := map[string]*flagValue{"project-root": {}}
+	fs.Var(values["project-root"], "project-root", "Project root directory (absolute path, defaults to CLAUDE_PROJECT_DIR or the discovered root)")
+
+	for _, s := range settings {
+		values[s.name] = &flagValue{value: s.get(c), isBool: s.isBool}
+		fs.Var(values[s.name], s.name, s.usage)
+	}
+	return values
+}
+
+// resolveProjectRoot applies the project root precedence and records its source
+func (c *Config) resolveProjectRoot(explicit *flagValue) error {
+	root, err := project.ResolveRoot(explicit.value)
+	if err != nil {
+		return err
+	}
+
+	c.ProjectRoot = root
+	switch {
+	case explicit.isSet:
+		c.sources["project-root"] = SourceFlag + " -project-root"
+	case os.Getenv(project.ProjectDirEnv) != "":
+		c.sources["project-root"] = SourceEnv + " " + project.ProjectDirEnv
+	case root != "":
+		c.sources["project-root"] = "discovered"
+	}
+	return nil
+}
+
+// loadFile applies settings from the JSON config or the TOML section
+func (c *Config) loadFile() error {
+	for _, candidate := range c.configFiles() {
+		values, err := readConfigFile(candidate)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read config %s: %w", candidate, err)
+		}
+
+		c.ConfigFile = candidate
+		return c.applyValues(values, SourceFile+" "+candidate)
+	}
+	return nil
+}
+
+// configFiles lists config file candidates in order of preference
+// Without a project root the current directory is used, like storage does.
+func (c *Config) configFiles() []string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "."
+	}
+	root := c.ProjectRoot
+	if root == "" {
+		root = cwd
+	}
+
+	candidates := []string{filepath.Join(append([]string{root}, JSONConfigPath...)...)}
+	if moduleRoot := findModuleRoot(cwd); moduleRoot != "" {
+		candidates = append(candidates, filepath.Join(moduleRoot, TOMLConfigFile))
+	}
+	return append(candidates, filepath.Join(root, TOMLConfigFile))
+}
+
+// readConfigFile decodes a JSON or TOML config file into raw values
+func readConfigFile(path string) (map[string]any, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if filepath.Ext(path) == ".toml" {
+		return parseTOMLSection(f, TOMLSection)
+	}
+
+	values := make(map[string]any)
+	if err := json.NewDecoder(f).Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// applyValues sets every value from a config file
+func (c *Config) applyValues(values map[string]any, source string) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s, exists := findSetting(name)
+		if !exists {
+			return fmt.Errorf("%s: unknown setting %q", source, name)
+		}
+		raw, err := toRaw(values[name])
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", source, name, err)
+		}
+		if err := c.apply(s, raw, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadEnv applies settings from TDD_GUARD_GO_* environment variables
+func (c *Config) loadEnv() error {
+	for _, s := range settings {
+		name := EnvName(s.name)
+		if value, exists := os.LookupEnv(name); exists && value != "" {
+			if err := c.apply(s, value, SourceEnv+" "+name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadFlags applies flags given on the command line
+func (c *Config) loadFlags(values map[string]*flagValue) error {
+	for _, s := range settings {
+		if value := values[s.name]; value.isSet {
+			if err := c.apply(s, value.value, SourceFlag+" -"+s.name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// apply parses a raw value into the setting and records its source
+func (c *Config) apply(s setting, value, source string) error {
+	if err := s.set(c, value); err != nil {
+		return fmt.Errorf("%s: %s: %w", source, s.name, err)
+	}
+	c.sources[s.name] = source
+	return nil
+}
+
+// EnvName returns the environment variable for a setting
+func EnvName(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+func findSetting(name string) (setting, bool) {
+	for _, s := range settings {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// findModuleRoot walks up from dir to the nearest go.mod
+func findModuleRoot(dir string) string {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// toRaw converts a decoded config value to its command line form
+func toRaw(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			raw, err := toRaw(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, raw)
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+func setChoice(target *string, value string, choices ...string) error {
+	for _, choice := range choices {
+		if value == choice {
+			*target = value
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q, expected one of %s", value, strings.Join(choices, ", "))
+}
+
+func setBool(target *bool, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", value)
+	}
+	*target = parsed
+	return nil
+}
+
+func setDuration(target *time.Duration, value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", value)
+	}
+	*target = parsed
+	return nil
+}
+
+func setInt(target *int, value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf("invalid non-negative integer %q", value)
+	}
+	*target = parsed
+	return nil
+}
+
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,271 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		c := Default()
+
+		t.Run("uses standard format", func(t *testing.T) {
+			if c.Format != FormatStandard {
+				t.Errorf("Expected format %q, got %q", FormatStandard, c.Format)
+			}
+		})
+
+		t.Run("does not merge", func(t *testing.T) {
+			if c.Merge {
+				t.Error("Expected merge to be disabled")
+			}
+		})
+
+		t.Run("keeps merged results for a day", func(t *testing.T) {
+			if c.MaxAge != 24*time.Hour {
+				t.Errorf("Expected max age 24h, got %v", c.MaxAge)
+			}
+		})
+
+		t.Run("reports default source", func(t *testing.T) {
+			if source := c.Source("merge"); source != SourceDefault {
+				t.Errorf("Expected source %q, got %q", SourceDefault, source)
+			}
+		})
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		t.Run("reads JSON config file", func(t *testing.T) {
+			root := setupProject(t)
+			writeJSONConfig(t, root, `{"merge": true, "max-age": "1h", "exclude-packages": ["**/mocks"]}`)
+
+			c := load(t)
+			if !c.Merge || c.MaxAge != time.Hour {
+				t.Errorf("Expected settings from file, got merge=%v max-age=%v", c.Merge, c.MaxAge)
+			}
+			if len(c.ExcludePackages) != 1 || c.ExcludePackages[0] != "**/mocks" {
+				t.Errorf("Expected exclude patterns from file, got %v", c.ExcludePackages)
+			}
+			if source := c.Source("merge"); !strings.HasPrefix(source, SourceFile) {
+				t.Errorf("Expected file source, got %q", source)
+			}
+		})
+
+		t.Run("reads TOML section at the module root", func(t *testing.T) {
+			root := setupProject(t)
+			writeFile(t, filepath.Join(root, TOMLConfigFile), `
+[tool.other]
+merge = false
+
+[tool.tdd-guard-go]
+merge = true
+max-error-bytes = 2048
+`)
+
+			c := load(t)
+			if !c.Merge || c.MaxErrorBytes != 2048 {
+				t.Errorf("Expected settings from TOML, got merge=%v max-error-bytes=%d", c.Merge, c.MaxErrorBytes)
+			}
+		})
+
+		t.Run("prefers JSON config over TOML", func(t *testing.T) {
+			root := setupProject(t)
+			writeJSONConfig(t, root, `{"verbosity": "quiet"}`)
+			writeFile(t, filepath.Join(root, TOMLConfigFile), "[tool.tdd-guard-go]\nverbosity = \"verbose\"\n")
+
+			if c := load(t); c.Verbosity != VerbosityQuiet {
+				t.Errorf("Expected verbosity from JSON, got %q", c.Verbosity)
+			}
+		})
+
+		t.Run("environment overrides file", func(t *testing.T) {
+			root := setupProject(t)
+			writeJSONConfig(t, root, `{"max-age": "1h"}`)
+			t.Setenv("TDD_GUARD_GO_MAX_AGE", "2h")
+
+			c := load(t)
+			if c.MaxAge != 2*time.Hour {
+				t.Errorf("Expected max age from environment, got %v", c.MaxAge)
+			}
+			if source := c.Source("max-age"); source != "env TDD_GUARD_GO_MAX_AGE" {
+				t.Errorf("Expected environment source, got %q", source)
+			}
+		})
+
+		t.Run("flags override environment", func(t *testing.T) {
+			setupProject(t)
+			t.Setenv("TDD_GUARD_GO_MAX_AGE", "2h")
+
+			c := load(t, "-max-age", "3h")
+			if c.MaxAge != 3*time.Hour {
+				t.Errorf("Expected max age from flag, got %v", c.MaxAge)
+			}
+			if source := c.Source("max-age"); source != "flag -max-age" {
+				t.Errorf("Expected flag source, got %q", source)
+			}
+		})
+
+		t.Run("accepts boolean flags without value", func(t *testing.T) {
+			setupProject(t)
+			if c := load(t, "-merge"); !c.Merge {
+				t.Error("Expected merge to be enabled")
+			}
+		})
+
+		t.Run("returns remaining arguments", func(t *testing.T) {
+			setupProject(t)
+			_, args, err := Load("test", []string{"-merge", "./..."}, io.Discard)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if len(args) != 1 || args[0] != "./..." {
+				t.Errorf("Expected remaining arguments, got %v", args)
+			}
+		})
+
+		t.Run("records discovered project root", func(t *testing.T) {
+			root := setupProject(t)
+			c := load(t)
+			if c.ProjectRoot != root || c.Source("project-root") != "discovered" {
+				t.Errorf("Expected discovered root %q, got %q from %q", root, c.ProjectRoot, c.Source("project-root"))
+			}
+		})
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Run("rejects unknown setting in file", func(t *testing.T) {
+			root := setupProject(t)
+			writeJSONConfig(t, root, `{"unknown": true}`)
+
+			assertLoadError(t, `unknown setting "unknown"`)
+		})
+
+		t.Run("rejects invalid value", func(t *testing.T) {
+			setupProject(t)
+			t.Setenv("TDD_GUARD_GO_FORMAT", "fancy")
+
+			assertLoadError(t, `invalid value "fancy"`)
+		})
+
+		t.Run("rejects malformed JSON", func(t *testing.T) {
+			root := setupProject(t)
+			writeJSONConfig(t, root, `{"merge":`)
+
+			assertLoadError(t, "read config")
+		})
+
+		t.Run("wraps flag errors as usage errors", func(t *testing.T) {
+			setupProject(t)
+			_, _, err := Load("test", []string{"-unknown"}, io.Discard)
+			if !errors.Is(err, ErrUsage) {
+				t.Errorf("Expected usage error, got: %v", err)
+			}
+		})
+
+		t.Run("returns help error", func(t *testing.T) {
+			setupProject(t)
+			_, _, err := Load("test", []string{"-h"}, io.Discard)
+			if !errors.Is(err, flag.ErrHelp) {
+				t.Errorf("Expected help error, got: %v", err)
+			}
+		})
+	})
+
+	t.Run("ResultsDir", func(t *testing.T) {
+		t.Run("defaults to the project data directory", func(t *testing.T) {
+			c := Default()
+			c.ProjectRoot = filepath.Join("/", "project")
+			expected := filepath.Join("/", "project", ".claude", "tdd-guard", "data")
+			if got := c.ResultsDir(); got != expected {
+				t.Errorf("Expected %q, got %q", expected, got)
+			}
+		})
+
+		t.Run("resolves relative data directory from project root", func(t *testing.T) {
+			c := Default()
+			c.ProjectRoot = filepath.Join("/", "project")
+			c.DataDir = "results"
+			if got := c.ResultsDir(); got != filepath.Join("/", "project", "results") {
+				t.Errorf("Expected data directory under project root, got %q", got)
+			}
+		})
+	})
+
+	t.Run("Show", func(t *testing.T) {
+		setupProject(t)
+		t.Setenv("TDD_GUARD_GO_MERGE", "true")
+		c := load(t, "-format", "raw")
+
+		var output bytes.Buffer
+		if err := c.Show(&output); err != nil {
+			t.Fatalf("Show failed: %v", err)
+		}
+
+		for _, expected := range []string{
+			"SETTING", "SOURCE",
+			"merge", "env TDD_GUARD_GO_MERGE",
+			"raw", "flag -format",
+			"max-age", "24h0m0s", "default",
+		} {
+			if !strings.Contains(output.String(), expected) {
+				t.Errorf("Expected output to contain %q, got:\n%s", expected, output.String())
+			}
+		}
+	})
+}
+
+// Helper functions
+
+// setupProject creates a module in a temp directory and makes it the current directory
+func setupProject(t *testing.T) string {
+	t.Helper()
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/project\n")
+	t.Chdir(root)
+	t.Setenv("CLAUDE_PROJECT_DIR", "")
+	for _, s := range settings {
+		t.Setenv(EnvName(s.name), "")
+	}
+	return root
+}
+
+func writeJSONConfig(t *testing.T, root, content string) {
+	t.Helper()
+	path := filepath.Join(append([]string{root}, JSONConfigPath...)...)
+	os.MkdirAll(filepath.Dir(path), 0755)
+	writeFile(t, path, content)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func load(t *testing.T, args ...string) *Config {
+	t.Helper()
+	c, _, err := Load("test", args, io.Discard)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return c
+}
+
+func assertLoadError(t *testing.T, expected string) {
+	t.Helper()
+	_, _, err := Load("test", nil, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Expected error containing %q, got: %v", expected, err)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/filter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/reporter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/writer"
+)
+
+// NewReporter creates a reporter with the configured settings
+func (c *Config) NewReporter() (*reporter.Reporter, error) {
+	r := reporter.NewReporter(c.ProjectRoot)
+	if c.DataDir != "" {
+		r.SetDataDir(c.ResultsDir())
+	}
+	if c.LockTimeout > 0 {
+		r.SetLockTimeout(c.LockTimeout)
+	}
+	err := r.SetFilter(reporter.Filter{
+		IncludePackages: c.IncludePackages,
+		ExcludePackages: c.ExcludePackages,
+		IncludeTests:    c.IncludeTests,
+		ExcludeTests:    c.ExcludeTests,
+		Mode:            filter.Mode(c.FilterMode),
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.SetMerge(c.Merge, c.MaxAge)
+	r.SetMaxErrorBytes(c.MaxErrorBytes)
+	if err := r.SetSlowThresholds(c.SlowThreshold, c.SlowThresholds, c.SlowStrict); err != nil {
+		return nil, err
+	}
+	if err := r.SetSlowBudgets(c.SlowBudgets); err != nil {
+		return nil, err
+	}
+	if c.Package != "" {
+		if err := r.SetPackage(c.Package); err != nil {
+			return nil, fmt.Errorf("%w, set -package to its import path", err)
+		}
+	}
+	if c.JUnitFile != "" {
+		r.AddSink(writer.NewJUnitWriter(c.JUnitFile))
+	}
+	if c.TAPFile != "" {
+		r.AddSink(writer.NewTAPWriter(c.TAPFile))
+	}
+	if c.MarkdownFile != "" {
+		r.AddSink(writer.NewMarkdownWriter(c.MarkdownFile))
+	}
+	return r, nil
+}
+
+// LoadReporter creates a reporter with the settings of the config file and
+// environment, merging results into the saved results since each test
+// binary reports its own package. Returns an error when the settings are
+// invalid.
+func LoadReporter(name string) (*reporter.Reporter, error) {
+	cfg, _, err := Load(name, nil, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	r, err := cfg.NewReporter()
+	if err != nil {
+		return nil, err
+	}
+	r.SetMerge(true, cfg.MaxAge)
+	return r, nil
+}
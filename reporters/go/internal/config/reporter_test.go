@@ -0,0 +1,126 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+)
+
+func TestNewReporter(t *testing.T) {
+	t.Run("saves results in the configured data directory", func(t *testing.T) {
+		c := Default()
+		c.ProjectRoot = t.TempDir()
+		c.DataDir = "results"
+
+		reporter, err := c.NewReporter()
+		if err != nil {
+			t.Fatalf("NewReporter failed: %v", err)
+		}
+
+		expected := filepath.Join(c.ProjectRoot, "results", "test.json")
+		if reporter.ResultsPath() != expected {
+			t.Errorf("Expected %s, got %s", expected, reporter.ResultsPath())
+		}
+	})
+
+	t.Run("names the configured package", func(t *testing.T) {
+		c := Default()
+		c.Package = "example.com/calc"
+
+		reporter, _ := c.NewReporter()
+
+		if reporter.Package() != "example.com/calc" {
+			t.Errorf("Expected example.com/calc, got %s", reporter.Package())
+		}
+	})
+
+	t.Run("rejects invalid settings", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			apply    func(c *Config)
+			expected string
+		}{
+			{"filter pattern", func(c *Config) { c.IncludeTests = []string{"re:("} }, "invalid test pattern"},
+			{"slow threshold", func(c *Config) { c.SlowThresholds = []string{"calc"} }, "calc"},
+			{"slow budget", func(c *Config) { c.SlowBudgets = []string{"calc"} }, "slow budget"},
+			{"test binary", func(c *Config) { c.Package = "calc.test" }, "set -package to its import path"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				c := Default()
+				tc.apply(c)
+
+				_, err := c.NewReporter()
+
+				if err == nil || !strings.Contains(err.Error(), tc.expected) {
+					t.Errorf("Expected error containing %q, got %v", tc.expected, err)
+				}
+			})
+		}
+	})
+}
+
+func TestLoadReporter(t *testing.T) {
+	t.Run("merges the results of each binary", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv("CLAUDE_PROJECT_DIR", root)
+		t.Chdir(root)
+
+		for _, pkg := range []string{"example.com/calc", "example.com/store"} {
+			reporter, err := LoadReporter("tdd-guard-go")
+			if err != nil {
+				t.Fatalf("LoadReporter failed: %v", err)
+			}
+			reporter.Add(parser.TestEvent{Action: "pass", Package: pkg, Test: "TestOne"})
+			if _, err := reporter.Finish(); err != nil {
+				t.Fatalf("Finish failed: %v", err)
+			}
+		}
+
+		reporter, err := LoadReporter("tdd-guard-go")
+		if err != nil {
+			t.Fatalf("LoadReporter failed: %v", err)
+		}
+		data, err := os.ReadFile(reporter.ResultsPath())
+		if err != nil {
+			t.Fatalf("Expected saved results: %v", err)
+		}
+		if !bytes.Contains(data, []byte("example.com/calc")) || !bytes.Contains(data, []byte("example.com/store")) {
+			t.Errorf("Expected both packages, got %s", data)
+		}
+	})
+
+	t.Run("returns invalid settings", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv("CLAUDE_PROJECT_DIR", root)
+		t.Chdir(root)
+		t.Setenv("TDD_GUARD_GO_SLOW_THRESHOLDS", "calc")
+
+		reporter, err := LoadReporter("tdd-guard-go-exec")
+
+		if err == nil || !strings.Contains(err.Error(), "calc") {
+			t.Errorf("Expected an error for the setting, got %v", err)
+		}
+		if reporter != nil {
+			t.Errorf("Expected no reporter, got %v", reporter)
+		}
+	})
+
+	t.Run("returns config file errors", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv("CLAUDE_PROJECT_DIR", root)
+		t.Chdir(root)
+		writeJSONConfig(t, root, `{"verbosity": "fancy"}`)
+
+		_, err := LoadReporter("tdd-guard-go-exec")
+
+		if err == nil || !strings.Contains(err.Error(), `invalid value "fancy"`) {
+			t.Errorf("Expected an error for the config file, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parseTOMLSection reads the key/value pairs of one table from a TOML document.
+// Only the subset needed for reporter settings is supported: strings, booleans,
+// integers and arrays of those, with arrays allowed to span several lines.
+func parseTOMLSection(reader io.Reader, section string) (map[string]any, error) {
+	values := make(map[string]any)
+	scanner := bufio.NewScanner(reader)
+	inSection := false
+	lineNumber := 0
+	pending := ""
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+
+		if pending != "" {
+			pending += " " + line
+			if !arrayClosed(pending) {
+				continue
+			}
+			line, pending = pending, ""
+		}
+
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && !strings.Contains(line, "=") {
+			inSection = strings.Trim(line, "[] \t") == section
+			continue
+		}
+
+		if !inSection {
+			continue
+		}
+
+		key, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		rawValue = strings.TrimSpace(rawValue)
+		if strings.HasPrefix(rawValue, "[") && !arrayClosed(rawValue) {
+			pending = line
+			continue
+		}
+
+		value, err := parseTOMLValue(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		values[unquoteKey(strings.TrimSpace(key))] = value
+	}
+
+	if pending != "" {
+		return nil, fmt.Errorf("line %d: unterminated array", lineNumber)
+	}
+	return values, scanner.Err()
+}
+
+// parseTOMLValue converts a single TOML value
+func parseTOMLValue(raw string) (any, error) {
+	switch {
+	case raw == "true":
+		return true, nil
+	case raw == "false":
+		return false, nil
+	case strings.HasPrefix(raw, `"`):
+		return strconv.Unquote(raw)
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	case strings.HasPrefix(raw, "["):
+		return parseTOMLArray(raw)
+	}
+
+	number, err := strconv.ParseInt(strings.ReplaceAll(raw, "_", ""), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported value %s", raw)
+	}
+	return float64(number), nil
+}
+
+// parseTOMLArray converts an array of scalar values
+func parseTOMLArray(raw string) ([]any, error) {
+	inner := strings.TrimSpace(raw[1 : len(raw)-1])
+	items := []any{}
+
+	for _, part := range splitArrayItems(inner) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		value, err := parseTOMLValue(part)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	return items, nil
+}
+
+// splitArrayItems splits on commas that are not inside quotes
+func splitArrayItems(inner string) []string {
+	var parts []string
+	var quote rune
+	start := 0
+
+	for i, r := range inner {
+		switch {
+		case quote != 0:
+			if r == quote && (quote == '\'' || i == 0 || inner[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			parts = append(parts, inner[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, inner[start:])
+}
+
+// arrayClosed checks if every opening bracket outside quotes has been closed
+func arrayClosed(raw string) bool {
+	depth := 0
+	var quote rune
+	for _, r := range raw {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// stripComment removes a trailing # comment outside of quotes
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteKey removes quotes from a quoted key
+func unquoteKey(key string) string {
+	if unquoted, err := strconv.Unquote(key); err == nil {
+		return unquoted
+	}
+	return strings.Trim(key, "'")
+}
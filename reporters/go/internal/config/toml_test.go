@@ -0,0 +1,97 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTOMLSection(t *testing.T) {
+	t.Run("reads values of the requested section only", func(t *testing.T) {
+		values := parseTOML(t, `
+name = "top level"
+
+[tool.tdd-guard-go]
+format = "raw"
+
+[tool.other]
+format = "standard"
+`)
+		expected := map[string]any{"format": "raw"}
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf("Expected %v, got %v", expected, values)
+		}
+	})
+
+	t.Run("Value types", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			line     string
+			expected any
+		}{
+			{"basic string", `key = "a \"quoted\" value"`, `a "quoted" value`},
+			{"literal string", `key = 'C:\path'`, `C:\path`},
+			{"boolean", `key = true`, true},
+			{"integer", `key = 1_024`, float64(1024)},
+			{"array", `key = ["a", 'b']`, []any{"a", "b"}},
+			{"array with commas in strings", `key = ["a,b", "c"]`, []any{"a,b", "c"}},
+			{"trailing comment", `key = "value" # comment`, "value"},
+			{"hash inside string", `key = "a#b"`, "a#b"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				values := parseTOML(t, "[tool.tdd-guard-go]\n"+tc.line)
+				if !reflect.DeepEqual(values["key"], tc.expected) {
+					t.Errorf("Expected %#v, got %#v", tc.expected, values["key"])
+				}
+			})
+		}
+	})
+
+	t.Run("reads arrays spanning several lines", func(t *testing.T) {
+		values := parseTOML(t, `
+[tool.tdd-guard-go]
+exclude-packages = [
+  "**/mocks", # generated
+  "**/e2e/...",
+]
+merge = true
+`)
+		expected := []any{"**/mocks", "**/e2e/..."}
+		if !reflect.DeepEqual(values["exclude-packages"], expected) {
+			t.Errorf("Expected %v, got %v", expected, values["exclude-packages"])
+		}
+		if values["merge"] != true {
+			t.Errorf("Expected value after array to be read, got %v", values["merge"])
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			input string
+		}{
+			{"missing value", "[tool.tdd-guard-go]\nkey"},
+			{"unsupported value", "[tool.tdd-guard-go]\nkey = 1.5"},
+			{"unterminated array", "[tool.tdd-guard-go]\nkey = [\"a\","},
+		}
+
+		for _, tc := range testCases {
+			t.Run("rejects "+tc.name, func(t *testing.T) {
+				if _, err := parseTOMLSection(strings.NewReader(tc.input), TOMLSection); err == nil {
+					t.Fatal("Expected error")
+				}
+			})
+		}
+	})
+}
+
+func parseTOML(t *testing.T, input string) map[string]any {
+	t.Helper()
+	values, err := parseTOMLSection(strings.NewReader(input), TOMLSection)
+	if err != nil {
+		t.Fatalf("parseTOMLSection failed: %v", err)
+	}
+	return values
+}
@@ -0,0 +1,242 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+)
+
+// RegexpPrefix marks a pattern as a regular expression instead of a glob
+const RegexpPrefix = "re:"
+
+// Mode controls what happens to filtered results
+type Mode string
+
+const (
+	// ModeExclude leaves filtered packages and tests out of the results
+	ModeExclude Mode = "exclude"
+	// ModeSkip keeps filtered tests in the results marked as skipped
+	ModeSkip Mode = "skip"
+)
+
+// Filter decides which packages and tests are included in the saved results.
+// Glob patterns are matched against the full import path or test name: "*"
+// and "?" don't cross a "/", while "**" and the go tool's "..." match any
+// sequence. Patterns starting with "re:" are regular expressions matched
+// against the whole value.
+type Filter struct {
+	include      []*regexp.Regexp
+	exclude      []*regexp.Regexp
+	includeTests []*regexp.Regexp
+	excludeTests []*regexp.Regexp
+	mode         Mode
+}
+
+// Summary counts the packages and tests removed or skipped by a filter
+type Summary struct {
+	Packages int
+	Tests    int
+}
+
+// NewFilter creates a filter from package include and exclude patterns.
+// Without include patterns every package not excluded is included.
+func NewFilter(include, exclude []string) (*Filter, error) {
+	f := &Filter{mode: ModeExclude}
+	var err error
+
+	if f.include, err = compilePatterns(include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = compilePatterns(exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SetTestPatterns filters tests by name within included packages.
+// A subtest is matched by its own name or the name of any parent test.
+func (f *Filter) SetTestPatterns(include, exclude []string) error {
+	var err error
+	if f.includeTests, err = compilePatterns(include); err != nil {
+		return err
+	}
+	if f.excludeTests, err = compilePatterns(exclude); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetMode changes whether filtered results are removed or marked skipped
+func (f *Filter) SetMode(mode Mode) {
+	f.mode = mode
+}
+
+// IncludesPackage checks if results for the package should be reported
+func (f *Filter) IncludesPackage(pkg string) bool {
+	if len(f.include) > 0 && !matchesAny(f.include, pkg) {
+		return false
+	}
+	return !matchesAny(f.exclude, pkg)
+}
+
+// IncludesTest checks if results for the test should be reported. Test name
+// patterns never filter compilation errors, so a build failure stays visible.
+func (f *Filter) IncludesTest(test string) bool {
+	if test == parser.CompilationErrorTest {
+		return true
+	}
+	if len(f.includeTests) > 0 && !matchesAnyParent(f.includeTests, test) {
+		return false
+	}
+	return !matchesAnyParent(f.excludeTests, test)
+}
+
+// Apply filters the results according to the mode. In exclude mode packages
+// whose tests are all filtered are removed. The input results are not modified.
+func (f *Filter) Apply(results parser.Results) (parser.Results, Summary) {
+	filtered := make(parser.Results)
+	var summary Summary
+
+	for pkg, tests := range results {
+		if !f.IncludesPackage(pkg) {
+			summary.Packages++
+			if f.mode == ModeSkip {
+				filtered[pkg] = skipAll(tests)
+			}
+			continue
+		}
+
+		kept := make(parser.PackageResults)
+		for test, state := range tests {
+			switch {
+			case f.IncludesTest(test):
+				kept[test] = state
+			case f.mode == ModeSkip:
+				summary.Tests++
+				kept[test] = parser.StateSkipped
+			default:
+				summary.Tests++
+			}
+		}
+		// In exclude mode a package left without tests is dropped entirely
+		if len(kept) > 0 || len(tests) == 0 {
+			filtered[pkg] = kept
+		}
+	}
+	return filtered, summary
+}
+
+// IsEmpty checks if nothing was filtered
+func (s Summary) IsEmpty() bool {
+	return s.Packages == 0 && s.Tests == 0
+}
+
+// String describes the counts, e.g. "filtered 2 packages and 1 test"
+func (s Summary) String() string {
+	var parts []string
+	if s.Packages > 0 {
+		parts = append(parts, plural(s.Packages, "package"))
+	}
+	if s.Tests > 0 {
+		parts = append(parts, plural(s.Tests, "test"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "filtered " + strings.Join(parts, " and ")
+}
+
+// skipAll marks every test of a filtered package as skipped
+func skipAll(tests parser.PackageResults) parser.PackageResults {
+	skipped := make(parser.PackageResults, len(tests))
+	for test, state := range tests {
+		if test == parser.CompilationErrorTest {
+			skipped[test] = state
+			continue
+		}
+		skipped[test] = parser.StateSkipped
+	}
+	return skipped
+}
+
+// CompilePattern converts a glob or regexp pattern, with the syntax filters
+// use, to an anchored regular expression
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	expr := globToRegexp(pattern)
+	if re, isRegexp := strings.CutPrefix(pattern, RegexpPrefix); isRegexp {
+		expr = "(?:" + re + ")"
+	}
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// compilePatterns converts glob and regexp patterns to anchored regular expressions
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := CompilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// globToRegexp translates glob wildcards into regular expression syntax
+func globToRegexp(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "..."):
+			b.WriteString(".*")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	return b.String()
+}
+
+// matchesAny checks if value matches at least one of the patterns
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, re := range patterns {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyParent checks the test name and each parent test name
+func matchesAnyParent(patterns []*regexp.Regexp, test string) bool {
+	for name := test; ; {
+		if matchesAny(patterns, name) {
+			return true
+		}
+		i := strings.LastIndex(name, "/")
+		if i < 0 {
+			return false
+		}
+		name = name[:i]
+	}
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+)
+
+func TestFilter(t *testing.T) {
+	t.Run("includes every package without patterns", func(t *testing.T) {
+		f := newFilter(t, nil, nil)
+		if !f.IncludesPackage("example.com/pkg") {
+			t.Fatal("Expected package to be included")
+		}
+	})
+
+	t.Run("Patterns", func(t *testing.T) {
+		testCases := []struct {
+			pattern  string
+			pkg      string
+			expected bool
+		}{
+			{"example.com/pkg", "example.com/pkg", true},
+			{"example.com/pkg", "example.com/pkg/sub", false},
+			{"example.com/*", "example.com/pkg", true},
+			{"example.com/*", "example.com/pkg/sub", false},
+			{"example.com/**", "example.com/pkg/sub", true},
+			{"example.com/...", "example.com/pkg/sub", true},
+			{"**/mocks", "example.com/internal/mocks", true},
+			{"**/mocks", "example.com/internal/mocksmith", false},
+			{"example.com/pkg?", "example.com/pkg2", true},
+			{"example.com/pkg.v2", "example.com/pkgXv2", false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.pattern+" against "+tc.pkg, func(t *testing.T) {
+				f := newFilter(t, []string{tc.pattern}, nil)
+				if got := f.IncludesPackage(tc.pkg); got != tc.expected {
+					t.Errorf("Expected %v, got %v", tc.expected, got)
+				}
+			})
+		}
+	})
+
+	t.Run("exclude wins over include", func(t *testing.T) {
+		f := newFilter(t, []string{"example.com/..."}, []string{"example.com/e2e/..."})
+		if f.IncludesPackage("example.com/e2e/api") {
+			t.Fatal("Expected excluded package to be filtered out")
+		}
+		if !f.IncludesPackage("example.com/api") {
+			t.Fatal("Expected included package to be kept")
+		}
+	})
+
+	t.Run("Regexp patterns", func(t *testing.T) {
+		f := newFilter(t, nil, []string{`re:example\.com/(mocks|fakes)`})
+		if f.IncludesPackage("example.com/fakes") {
+			t.Error("Expected package matching regexp to be excluded")
+		}
+		if !f.IncludesPackage("example.com/fakes/sub") {
+			t.Error("Expected regexp to match the whole import path")
+		}
+	})
+
+	t.Run("rejects invalid regexp", func(t *testing.T) {
+		if _, err := NewFilter([]string{"re:("}, nil); err == nil {
+			t.Fatal("Expected error for invalid regexp")
+		}
+	})
+
+	t.Run("Test patterns", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			include  []string
+			exclude  []string
+			test     string
+			expected bool
+		}{
+			{"includes every test without patterns", nil, nil, "TestA", true},
+			{"excludes matching test", nil, []string{"TestE2E*"}, "TestE2ELogin", false},
+			{"excludes subtests of matching test", nil, []string{"TestE2E*"}, "TestE2ELogin/admin", false},
+			{"glob does not cross subtest boundary", nil, []string{"TestA*"}, "TestB/TestA", true},
+			{"includes only matching tests", []string{"TestUnit*"}, nil, "TestE2ELogin", false},
+			{"includes subtests of included test", []string{"TestUnit*"}, nil, "TestUnitAdd/negative", true},
+			{"excludes by regexp", nil, []string{"re:.*Integration$"}, "TestDBIntegration", false},
+			{"keeps compilation errors", []string{"TestUnit*"}, nil, "CompilationError", true},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				f := newFilter(t, nil, nil)
+				if err := f.SetTestPatterns(tc.include, tc.exclude); err != nil {
+					t.Fatalf("SetTestPatterns failed: %v", err)
+				}
+				if got := f.IncludesTest(tc.test); got != tc.expected {
+					t.Errorf("Expected %v, got %v", tc.expected, got)
+				}
+			})
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		results := parser.Results{
+			"example.com/api": {
+				"TestA":      parser.StatePassed,
+				"TestE2E":    parser.StateFailed,
+				"TestE2E/ok": parser.StatePassed,
+			},
+			"example.com/mocks": {"TestB": parser.StateFailed},
+		}
+
+		t.Run("removes excluded packages and tests", func(t *testing.T) {
+			f := newTestFilter(t, []string{"**/mocks"}, []string{"TestE2E"})
+
+			filtered, _ := f.Apply(results)
+			expected := parser.Results{"example.com/api": {"TestA": parser.StatePassed}}
+			if !reflect.DeepEqual(filtered, expected) {
+				t.Errorf("Expected %v, got %v", expected, filtered)
+			}
+		})
+
+		t.Run("removes packages whose tests are all excluded", func(t *testing.T) {
+			f := newTestFilter(t, nil, []string{"TestB"})
+
+			filtered, _ := f.Apply(results)
+			if _, exists := filtered["example.com/mocks"]; exists {
+				t.Errorf("Expected example.com/mocks to be removed, got %v", filtered)
+			}
+		})
+
+		t.Run("keeps packages without tests", func(t *testing.T) {
+			f := newTestFilter(t, nil, []string{"TestB"})
+
+			filtered, _ := f.Apply(parser.Results{"example.com/empty": {}})
+			if _, exists := filtered["example.com/empty"]; !exists {
+				t.Errorf("Expected example.com/empty to be kept, got %v", filtered)
+			}
+		})
+
+		t.Run("counts filtered packages and tests", func(t *testing.T) {
+			f := newTestFilter(t, []string{"**/mocks"}, []string{"TestE2E"})
+
+			_, summary := f.Apply(results)
+			if summary != (Summary{Packages: 1, Tests: 2}) {
+				t.Errorf("Expected 1 package and 2 tests, got %+v", summary)
+			}
+		})
+
+		t.Run("marks filtered results skipped in skip mode", func(t *testing.T) {
+			f := newTestFilter(t, []string{"**/mocks"}, []string{"TestE2E"})
+			f.SetMode(ModeSkip)
+
+			filtered, _ := f.Apply(results)
+			expected := parser.Results{
+				"example.com/api": {
+					"TestA":      parser.StatePassed,
+					"TestE2E":    parser.StateSkipped,
+					"TestE2E/ok": parser.StateSkipped,
+				},
+				"example.com/mocks": {"TestB": parser.StateSkipped},
+			}
+			if !reflect.DeepEqual(filtered, expected) {
+				t.Errorf("Expected %v, got %v", expected, filtered)
+			}
+		})
+
+		t.Run("does not modify input", func(t *testing.T) {
+			f := newTestFilter(t, nil, []string{"TestA"})
+			f.SetMode(ModeSkip)
+
+			f.Apply(results)
+			if results["example.com/api"]["TestA"] != parser.StatePassed {
+				t.Error("Expected input results to be unchanged")
+			}
+		})
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		testCases := []struct {
+			summary  Summary
+			expected string
+		}{
+			{Summary{}, ""},
+			{Summary{Packages: 1}, "filtered 1 package"},
+			{Summary{Tests: 3}, "filtered 3 tests"},
+			{Summary{Packages: 2, Tests: 1}, "filtered 2 packages and 1 test"},
+		}
+
+		for _, tc := range testCases {
+			if got := tc.summary.String(); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		}
+	})
+}
+
+func newFilter(t *testing.T, include, exclude []string) *Filter {
+	t.Helper()
+	f, err := NewFilter(include, exclude)
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	return f
+}
+
+func newTestFilter(t *testing.T, excludePackages, excludeTests []string) *Filter {
+	t.Helper()
+	f := newFilter(t, nil, excludePackages)
+	if err := f.SetTestPatterns(nil, excludeTests); err != nil {
+		t.Fatalf("SetTestPatterns failed: %v", err)
+	}
+	return f
+}
@@ -0,0 +1,95 @@
+package formatter
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/style"
+)
+
+// ColorMode selects when terminal output is colored
+type ColorMode = style.ColorMode
+
+// Supported color modes, described in the style package
+const (
+	ColorAuto   = style.ColorAuto
+	ColorAlways = style.ColorAlways
+	ColorNever  = style.ColorNever
+)
+
+// ANSI escape sequences
+const (
+	ansiReset   = "\x1b[0m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiCyan    = "\x1b[36m"
+	ansiBoldRed = "\x1b[1;31m"
+)
+
+// sourceLocation matches file:line references in test and compiler output
+var sourceLocation = regexp.MustCompile(`[\w./\\-]+\.go:\d+(?::\d+)?`)
+
+// UseColor decides whether output written to w is colored. In auto mode
+// NO_COLOR disables color, FORCE_COLOR enables it, and otherwise only
+// terminals get color.
+func UseColor(mode ColorMode, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0" && force != "false"
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(w)
+}
+
+// isTerminal checks if w is a character device such as a terminal
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Colorize colors each line by the result it reports and highlights
+// source locations in other lines
+func Colorize(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = colorizeLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// colorizeLine colors a single line of formatted output
+func colorizeLine(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	switch {
+	case strings.HasPrefix(trimmed, "BUILD FAILED"), strings.HasSuffix(trimmed, "[build failed]"),
+		strings.HasSuffix(trimmed, "[setup failed]"):
+		return ansiBoldRed + line + ansiReset
+	case strings.HasPrefix(trimmed, "FAIL"), strings.HasPrefix(trimmed, "--- FAIL"),
+		strings.HasPrefix(trimmed, symbolFailed+" "):
+		return ansiRed + line + ansiReset
+	case strings.HasPrefix(trimmed, "ok  "), strings.HasPrefix(trimmed, "--- PASS"),
+		strings.HasPrefix(trimmed, symbolPassed+" "):
+		return ansiGreen + line + ansiReset
+	case strings.HasPrefix(trimmed, "?   "), strings.HasPrefix(trimmed, "--- SKIP"),
+		strings.HasPrefix(trimmed, symbolSkipped+" "):
+		return ansiYellow + line + ansiReset
+	}
+	return sourceLocation.ReplaceAllString(line, ansiCyan+"$0"+ansiReset)
+}
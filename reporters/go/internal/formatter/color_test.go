@@ -0,0 +1,108 @@
+package formatter
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+)
+
+func TestColor(t *testing.T) {
+	t.Run("colors results by state", func(t *testing.T) {
+		f := NewFormatter()
+		f.SetColor(true)
+
+		tests := []struct {
+			event    parser.TestEvent
+			expected string
+		}{
+			{parser.TestEvent{Action: "pass", Package: "example.com/a", Elapsed: 0.1}, ansiGreen + "ok  \texample.com/a\t0.100s" + ansiReset},
+			{parser.TestEvent{Action: "fail", Package: "example.com/a", Elapsed: 0.1}, ansiRed + "FAIL\texample.com/a\t0.100s" + ansiReset},
+			{parser.TestEvent{Action: "fail", Package: "example.com/a", FailedBuild: "example.com/a"}, ansiBoldRed + "FAIL\texample.com/a [build failed]" + ansiReset},
+			{parser.TestEvent{Action: "output", Package: "example.com/a", Output: "?   \texample.com/a\t[no test files]\n"}, ansiYellow + "?   \texample.com/a\t[no test files]" + ansiReset},
+		}
+		for _, tt := range tests {
+			if got := f.Format(tt.event); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		}
+	})
+
+	t.Run("highlights source locations in error output", func(t *testing.T) {
+		f := NewFormatter()
+		f.SetColor(true)
+
+		got := f.Format(parser.TestEvent{Action: "output", Package: "example.com/a", Test: "TestA", Output: "    calc_test.go:12: expected 2\n"})
+
+		expected := "    " + ansiCyan + "calc_test.go:12" + ansiReset + ": expected 2"
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("colors compact style symbols", func(t *testing.T) {
+		f := NewFormatterWithStyle(StyleTestname)
+		f.SetColor(true)
+
+		got := f.Format(parser.TestEvent{Action: "pass", Package: "example.com/a", Test: "TestA"})
+
+		if !strings.HasPrefix(got, ansiGreen+symbolPassed) {
+			t.Errorf("Expected green test line, got %q", got)
+		}
+	})
+
+	t.Run("leaves output plain by default", func(t *testing.T) {
+		got := NewFormatter().Format(parser.TestEvent{Action: "fail", Package: "example.com/a"})
+
+		if strings.Contains(got, "\x1b[") {
+			t.Errorf("Expected no escape codes, got %q", got)
+		}
+	})
+}
+
+func TestUseColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	t.Run("follows explicit modes", func(t *testing.T) {
+		if !UseColor(ColorAlways, &bytes.Buffer{}) {
+			t.Error("Expected always to enable color")
+		}
+		t.Setenv("FORCE_COLOR", "1")
+		if UseColor(ColorNever, &bytes.Buffer{}) {
+			t.Error("Expected never to disable color")
+		}
+	})
+
+	t.Run("disables color for files and buffers", func(t *testing.T) {
+		file, _ := os.Create(filepath.Join(t.TempDir(), "output.txt"))
+		defer file.Close()
+
+		if UseColor(ColorAuto, file) || UseColor(ColorAuto, &bytes.Buffer{}) {
+			t.Error("Expected no color outside terminals")
+		}
+	})
+
+	t.Run("honors FORCE_COLOR", func(t *testing.T) {
+		t.Setenv("FORCE_COLOR", "1")
+		if !UseColor(ColorAuto, &bytes.Buffer{}) {
+			t.Error("Expected FORCE_COLOR to enable color")
+		}
+		t.Setenv("FORCE_COLOR", "0")
+		if UseColor(ColorAuto, &bytes.Buffer{}) {
+			t.Error("Expected FORCE_COLOR=0 to keep color off")
+		}
+	})
+
+	t.Run("NO_COLOR takes precedence over FORCE_COLOR", func(t *testing.T) {
+		t.Setenv("FORCE_COLOR", "1")
+		t.Setenv("NO_COLOR", "1")
+		if UseColor(ColorAuto, &bytes.Buffer{}) {
+			t.Error("Expected NO_COLOR to disable color")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
@@ -11,13 +12,35 @@ import (
 // It reduces verbose JSON output to concise, human-readable test results.
 type Formatter struct {
 	handlers map[string]eventHandler
+	packages map[string]*packageProgress
+	color    bool
+	marks    bool   // Write prints a mark as each test ends
+	openLine string // Package whose line Write has started but not ended
 }
 
 type eventHandler func(event parser.TestEvent) string
 
 func NewFormatter() *Formatter {
-	f := &Formatter{}
-	f.initHandlers()
+	return NewFormatterWithStyle(StyleStandardQuiet)
+}
+
+// NewFormatterWithStyle creates a formatter rendering events in the style.
+// Unknown styles fall back to standard-quiet.
+func NewFormatterWithStyle(style Style) *Formatter {
+	f := &Formatter{packages: make(map[string]*packageProgress)}
+	switch style {
+	case StyleStandardVerbose:
+		f.initVerboseHandlers()
+	case StyleDots:
+		f.initCompactHandlers(f.dotsPackageLine, nil)
+		f.marks = true
+	case StyleTestname:
+		f.initCompactHandlers(f.standardPackageLine, f.testnameLine)
+	case StylePkgname:
+		f.initCompactHandlers(f.pkgnamePackageLine, nil)
+	default:
+		f.initHandlers()
+	}
 	return f
 }
 
@@ -27,6 +50,8 @@ func (f *Formatter) initHandlers() {
 	f.handlers = map[string]eventHandler{
 		"start":        returnEmpty,
 		"run":          returnEmpty,
+		"pause":        returnEmpty, // t.Parallel lifecycle, not a result
+		"cont":         returnEmpty,
 		"build-output": f.handleBuildOutput,
 		"build-fail":   f.handleBuildFail,
 		"output":       f.handleOutput,
@@ -35,12 +60,66 @@ func (f *Formatter) initHandlers() {
 	}
 }
 
+// SetColor enables ANSI colors for results and source locations
+func (f *Formatter) SetColor(enabled bool) {
+	f.color = enabled
+}
+
 func (f *Formatter) Format(event parser.TestEvent) string {
 	handler, exists := f.handlers[event.Action]
 	if !exists {
-		return f.handleUnknown(event)
+		handler = f.handleUnknown
+	}
+	formatted := handler(event)
+	if f.color && formatted != "" {
+		return Colorize(formatted)
+	}
+	return formatted
+}
+
+// Write prints the formatted event to w as a line. In the dots style the
+// mark of each finished test is printed right away, so a package's line
+// grows while it runs and ends when the package does.
+func (f *Formatter) Write(w io.Writer, event parser.TestEvent) {
+	if f.marks && event.Test != "" && testMarks[event.Action] != "" {
+		f.Format(event)
+		if f.openLine != event.Package {
+			f.endLine(w)
+			fmt.Fprintf(w, "%s ", event.Package)
+			f.openLine = event.Package
+			f.progress(event.Package).live = true
+		}
+		fmt.Fprint(w, testMarks[event.Action])
+		return
+	}
+
+	formatted := f.Format(event)
+	if formatted == "" {
+		return
+	}
+	// Only the package's result completes the line of its marks
+	if f.openLine != event.Package || event.Test != "" || testMarks[event.Action] == "" {
+		f.endLine(w)
+	}
+	f.openLine = ""
+	fmt.Fprintln(w, formatted)
+}
+
+// WriteLine prints a line that is not an event to w, after ending the line
+// of a running package
+func (f *Formatter) WriteLine(w io.Writer, line string) {
+	f.endLine(w)
+	fmt.Fprintln(w, line)
+}
+
+// endLine ends the line started by Write, if any. The package's line is
+// then printed in full once it ends.
+func (f *Formatter) endLine(w io.Writer) {
+	if f.openLine != "" {
+		fmt.Fprintln(w)
+		f.progress(f.openLine).live = false
+		f.openLine = ""
 	}
-	return handler(event)
 }
 
 func (f *Formatter) handleBuildOutput(event parser.TestEvent) string {
@@ -76,8 +155,8 @@ func (f *Formatter) handleOutput(event parser.TestEvent) string {
 
 	if event.Test != "" {
 		switch {
-		case strings.HasPrefix(output, "=== RUN"), strings.HasPrefix(output, "--- PASS:"):
-			return "" // Filter verbose test markers
+		case strings.HasPrefix(output, "=== "), strings.HasPrefix(output, "--- PASS:"):
+			return "" // Filter verbose test markers, including PAUSE, CONT and NAME
 		case strings.HasPrefix(output, "--- FAIL:"), strings.Contains(output, ":"):
 			return trimNewline(output) // Keep failure details and error messages
 		default:
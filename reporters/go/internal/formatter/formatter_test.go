@@ -243,6 +243,20 @@ func TestFormatter(t *testing.T) {
 		}
 	})
 
+	t.Run("TestFilterParallelLifecycle", func(t *testing.T) {
+		for _, event := range []parser.TestEvent{
+			{Action: "pause", Package: "example", Test: "TestA"},
+			{Action: "cont", Package: "example", Test: "TestA"},
+			{Action: "output", Package: "example", Test: "TestA", Output: "=== PAUSE TestA\n"},
+			{Action: "output", Package: "example", Test: "TestA", Output: "=== CONT  TestA\n"},
+			{Action: "output", Package: "example", Test: "TestA", Output: "=== NAME  TestA\n"},
+		} {
+			if result := formatEvent(t, event); result != "" {
+				t.Errorf("Expected %s event to be filtered, got '%s'", event.Action, result)
+			}
+		}
+	})
+
 	t.Run("TestPassThroughUnknownActions", func(t *testing.T) {
 		result := formatEvent(t, parser.TestEvent{
 			Action: "unknown-action",
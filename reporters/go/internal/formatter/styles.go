@@ -0,0 +1,244 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/style"
+)
+
+// Style selects how go test events are rendered in the terminal
+type Style = style.Style
+
+// Supported styles, described in the style package
+const (
+	StyleStandardQuiet   = style.StandardQuiet
+	StyleStandardVerbose = style.StandardVerbose
+	StyleDots            = style.Dots
+	StyleTestname        = style.Testname
+	StylePkgname         = style.Pkgname
+)
+
+// Styles lists every supported style
+var Styles = style.Styles
+
+// Symbols and marks used by the compact styles
+const (
+	symbolPassed  = "✓"
+	symbolFailed  = "✗"
+	symbolSkipped = "∅"
+
+	markPassed  = "·"
+	markFailed  = "✖"
+	markSkipped = "↷"
+)
+
+// testMarks maps the actions ending a test to their marks
+var testMarks = map[string]string{"pass": markPassed, "fail": markFailed, "skip": markSkipped}
+
+// packageProgress collects the results of a running package for compact styles
+type packageProgress struct {
+	passed   int
+	failed   int
+	skipped  int
+	marks    strings.Builder
+	output   map[string][]string // lines logged by each running test
+	failures []string            // output of the package's failed tests
+	live     bool                // marks were printed as the tests ended
+}
+
+// packageLineFunc renders the line for a finished package
+type packageLineFunc func(event parser.TestEvent, progress *packageProgress) string
+
+// testLineFunc renders the line for a finished test with its logged lines
+type testLineFunc func(event parser.TestEvent, lines []string) string
+
+// initVerboseHandlers passes the output of go test through unchanged
+func (f *Formatter) initVerboseHandlers() {
+	returnEmpty := func(event parser.TestEvent) string { return "" }
+	passOutput := func(event parser.TestEvent) string { return trimNewline(event.Output) }
+
+	f.handlers = map[string]eventHandler{
+		"start":        returnEmpty,
+		"run":          returnEmpty,
+		"pause":        returnEmpty,
+		"cont":         returnEmpty,
+		"pass":         returnEmpty,
+		"fail":         returnEmpty,
+		"skip":         returnEmpty,
+		"build-fail":   returnEmpty,
+		"build-output": passOutput,
+		"output":       passOutput,
+	}
+}
+
+// initCompactHandlers collects test results and renders a line when each
+// package finishes, followed by the output of its failed tests. When
+// testLine is set, each test gets a line instead, with its failure output.
+func (f *Formatter) initCompactHandlers(packageLine packageLineFunc, testLine testLineFunc) {
+	returnEmpty := func(event parser.TestEvent) string { return "" }
+	finish := func(event parser.TestEvent) string {
+		if event.Test != "" {
+			return f.finishTest(event, testLine)
+		}
+		return f.finishPackage(event, packageLine)
+	}
+
+	f.handlers = map[string]eventHandler{
+		"start":        returnEmpty,
+		"run":          returnEmpty,
+		"pause":        returnEmpty,
+		"cont":         returnEmpty,
+		"build-output": f.handleBuildOutput,
+		"build-fail":   f.handleBuildFail,
+		"output":       f.handleCompactOutput,
+		"pass":         finish,
+		"fail":         finish,
+		"skip":         finish,
+	}
+}
+
+// progress returns the collected results of a package
+func (f *Formatter) progress(pkg string) *packageProgress {
+	p, exists := f.packages[pkg]
+	if !exists {
+		p = &packageProgress{output: make(map[string][]string)}
+		f.packages[pkg] = p
+	}
+	return p
+}
+
+// handleCompactOutput keeps test output until the test finishes and drops
+// the package summaries go test prints
+func (f *Formatter) handleCompactOutput(event parser.TestEvent) string {
+	output := event.Output
+	trimmed := strings.TrimLeft(output, " \t")
+
+	if event.Test != "" {
+		if strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "--- PASS:") ||
+			strings.HasPrefix(trimmed, "--- SKIP:") {
+			return ""
+		}
+		p := f.progress(event.Package)
+		p.output[event.Test] = append(p.output[event.Test], trimNewline(output))
+		return ""
+	}
+
+	switch {
+	case output == "PASS\n", output == "FAIL\n",
+		strings.HasPrefix(output, "ok  \t"), strings.HasPrefix(output, "FAIL\t"),
+		strings.HasPrefix(output, "?   \t"), strings.HasPrefix(output, "exit status"):
+		return ""
+	}
+	return trimNewline(output)
+}
+
+// finishTest counts a finished test and renders its line when the style
+// has one; otherwise failure output is kept for the package line
+func (f *Formatter) finishTest(event parser.TestEvent, testLine testLineFunc) string {
+	p := f.progress(event.Package)
+	lines := p.output[event.Test]
+	delete(p.output, event.Test)
+
+	switch event.Action {
+	case "pass":
+		p.passed++
+	case "fail":
+		p.failed++
+	case "skip":
+		p.skipped++
+	}
+	p.marks.WriteString(testMarks[event.Action])
+
+	if testLine != nil {
+		return testLine(event, lines)
+	}
+	if event.Action == "fail" {
+		p.failures = append(p.failures, lines...)
+	}
+	return ""
+}
+
+// finishPackage renders the package line followed by failure output
+func (f *Formatter) finishPackage(event parser.TestEvent, packageLine packageLineFunc) string {
+	if event.Package == "" {
+		return ""
+	}
+	p := f.progress(event.Package)
+	delete(f.packages, event.Package)
+
+	return strings.Join(append([]string{packageLine(event, p)}, p.failures...), "\n")
+}
+
+// testnameLine renders a test with its symbol and time, followed by the
+// output of a failed test
+func (f *Formatter) testnameLine(event parser.TestEvent, lines []string) string {
+	line := fmt.Sprintf("%s %s/%s (%.2fs)", actionSymbol(event.Action), event.Package, event.Test, event.Elapsed)
+	if event.Action != "fail" {
+		return line
+	}
+	return strings.Join(append([]string{line}, lines...), "\n")
+}
+
+// standardPackageLine renders the package summary of the standard style
+func (f *Formatter) standardPackageLine(event parser.TestEvent, p *packageProgress) string {
+	switch event.Action {
+	case "pass":
+		return f.handlePass(event)
+	case "fail":
+		return f.handleFail(event)
+	}
+	return fmt.Sprintf("?   \t%s\t[no test files]", event.Package)
+}
+
+// dotsPackageLine renders the package with a mark per test and its result,
+// or only the result when Write already printed the package and its marks
+func (f *Formatter) dotsPackageLine(event parser.TestEvent, p *packageProgress) string {
+	if event.FailedBuild != "" {
+		return fmt.Sprintf("%s %s [build failed]", symbolFailed, event.Package)
+	}
+	if event.Action == "skip" && p.marks.Len() == 0 {
+		return fmt.Sprintf("%s %s", symbolSkipped, event.Package)
+	}
+	result := fmt.Sprintf("%s (%.3fs)", actionSymbol(event.Action), event.Elapsed)
+	if p.live {
+		return " " + result
+	}
+	return fmt.Sprintf("%s %s %s", event.Package, p.marks.String(), result)
+}
+
+// pkgnamePackageLine renders the package with its test counts and time
+func (f *Formatter) pkgnamePackageLine(event parser.TestEvent, p *packageProgress) string {
+	if event.FailedBuild != "" {
+		return fmt.Sprintf("%s %s [build failed]", symbolFailed, event.Package)
+	}
+	if event.Action == "skip" {
+		return fmt.Sprintf("%s %s (no test files)", symbolSkipped, event.Package)
+	}
+
+	var counts []string
+	for _, count := range []struct {
+		n     int
+		label string
+	}{{p.passed, "passed"}, {p.failed, "failed"}, {p.skipped, "skipped"}} {
+		if count.n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count.n, count.label))
+		}
+	}
+	if len(counts) == 0 {
+		counts = append(counts, "no tests")
+	}
+	return fmt.Sprintf("%s %s (%s) %.3fs", actionSymbol(event.Action), event.Package, strings.Join(counts, ", "), event.Elapsed)
+}
+
+// actionSymbol returns the symbol for a pass, fail or skip action
+func actionSymbol(action string) string {
+	switch action {
+	case "fail":
+		return symbolFailed
+	case "skip":
+		return symbolSkipped
+	}
+	return symbolPassed
+}
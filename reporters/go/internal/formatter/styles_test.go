@@ -0,0 +1,148 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+)
+
+func TestStyles(t *testing.T) {
+	events := []parser.TestEvent{
+		{Action: "start", Package: "example.com/calc"},
+		{Action: "run", Package: "example.com/calc", Test: "TestAdd"},
+		{Action: "output", Package: "example.com/calc", Test: "TestAdd", Output: "=== RUN   TestAdd\n"},
+		{Action: "output", Package: "example.com/calc", Test: "TestAdd", Output: "--- PASS: TestAdd (0.00s)\n"},
+		{Action: "pass", Package: "example.com/calc", Test: "TestAdd", Elapsed: 0.01},
+		{Action: "run", Package: "example.com/calc", Test: "TestDivide"},
+		{Action: "output", Package: "example.com/calc", Test: "TestDivide", Output: "=== RUN   TestDivide\n"},
+		{Action: "output", Package: "example.com/calc", Test: "TestDivide", Output: "    calc_test.go:12: division by zero\n"},
+		{Action: "output", Package: "example.com/calc", Test: "TestDivide", Output: "--- FAIL: TestDivide (0.02s)\n"},
+		{Action: "fail", Package: "example.com/calc", Test: "TestDivide", Elapsed: 0.02},
+		{Action: "output", Package: "example.com/calc", Test: "TestNetwork", Output: "--- SKIP: TestNetwork (0.00s)\n"},
+		{Action: "skip", Package: "example.com/calc", Test: "TestNetwork"},
+		{Action: "output", Package: "example.com/calc", Output: "FAIL\n"},
+		{Action: "output", Package: "example.com/calc", Output: "FAIL\texample.com/calc\t0.031s\n"},
+		{Action: "fail", Package: "example.com/calc", Elapsed: 0.031},
+		{Action: "output", Package: "example.com/empty", Output: "?   \texample.com/empty\t[no test files]\n"},
+		{Action: "skip", Package: "example.com/empty"},
+	}
+
+	t.Run("standard-quiet is the default style", func(t *testing.T) {
+		if formatAll(NewFormatterWithStyle(StyleStandardQuiet), events) != formatAll(NewFormatter(), events) {
+			t.Error("Expected standard-quiet to match the default formatter")
+		}
+	})
+
+	t.Run("standard-verbose passes output through", func(t *testing.T) {
+		output := formatAll(NewFormatterWithStyle(StyleStandardVerbose), events)
+
+		expected := "=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\n=== RUN   TestDivide\n"
+		if !strings.HasPrefix(output, expected) {
+			t.Errorf("Expected go test output, got:\n%s", output)
+		}
+		if strings.Contains(output, "skip:") {
+			t.Errorf("Expected no lifecycle lines, got:\n%s", output)
+		}
+	})
+
+	t.Run("dots prints a mark per test for each package", func(t *testing.T) {
+		output := formatAll(NewFormatterWithStyle(StyleDots), events)
+
+		expected := "example.com/calc ·✖↷ ✗ (0.031s)\n    calc_test.go:12: division by zero\n--- FAIL: TestDivide (0.02s)\n∅ example.com/empty"
+		if output != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+		}
+	})
+
+	t.Run("dots prints each mark as its test ends", func(t *testing.T) {
+		f := NewFormatterWithStyle(StyleDots)
+		var output strings.Builder
+
+		for _, event := range events[:5] {
+			f.Write(&output, event)
+		}
+		if output.String() != "example.com/calc ·" {
+			t.Errorf("Expected the mark of TestAdd, got %q", output.String())
+		}
+
+		for _, event := range events[5:] {
+			f.Write(&output, event)
+		}
+		expected := "example.com/calc ·✖↷ ✗ (0.031s)\n    calc_test.go:12: division by zero\n--- FAIL: TestDivide (0.02s)\n∅ example.com/empty\n"
+		if output.String() != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+		}
+	})
+
+	t.Run("dots ends a line of marks before other output", func(t *testing.T) {
+		f := NewFormatterWithStyle(StyleDots)
+		var output strings.Builder
+
+		f.Write(&output, parser.TestEvent{Action: "pass", Package: "example.com/calc", Test: "TestAdd"})
+		f.WriteLine(&output, "warning: unrelated")
+		f.Write(&output, parser.TestEvent{Action: "pass", Package: "example.com/calc", Elapsed: 0.01})
+
+		expected := "example.com/calc ·\nwarning: unrelated\nexample.com/calc · ✓ (0.010s)\n"
+		if output.String() != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+		}
+	})
+
+	t.Run("testname prints a line per test", func(t *testing.T) {
+		output := formatAll(NewFormatterWithStyle(StyleTestname), events)
+
+		expected := strings.Join([]string{
+			"✓ example.com/calc/TestAdd (0.01s)",
+			"✗ example.com/calc/TestDivide (0.02s)",
+			"    calc_test.go:12: division by zero",
+			"--- FAIL: TestDivide (0.02s)",
+			"∅ example.com/calc/TestNetwork (0.00s)",
+			"FAIL\texample.com/calc\t0.031s",
+			"?   \texample.com/empty\t[no test files]",
+		}, "\n")
+		if output != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+		}
+	})
+
+	t.Run("pkgname prints a line per package with counts", func(t *testing.T) {
+		output := formatAll(NewFormatterWithStyle(StylePkgname), events)
+
+		expected := strings.Join([]string{
+			"✗ example.com/calc (1 passed, 1 failed, 1 skipped) 0.031s",
+			"    calc_test.go:12: division by zero",
+			"--- FAIL: TestDivide (0.02s)",
+			"∅ example.com/empty (no test files)",
+		}, "\n")
+		if output != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+		}
+	})
+
+	t.Run("compact styles report build failures", func(t *testing.T) {
+		buildEvents := []parser.TestEvent{
+			{Action: "build-output", ImportPath: "example.com/broken", Output: "./broken.go:3:1: syntax error\n"},
+			{Action: "fail", Package: "example.com/broken", FailedBuild: "example.com/broken"},
+		}
+
+		output := formatAll(NewFormatterWithStyle(StylePkgname), buildEvents)
+
+		expected := "./broken.go:3:1: syntax error\n✗ example.com/broken [build failed]"
+		if output != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+		}
+	})
+}
+
+// Helper functions
+
+func formatAll(f *Formatter, events []parser.TestEvent) string {
+	var lines []string
+	for _, event := range events {
+		if line := f.Format(event); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
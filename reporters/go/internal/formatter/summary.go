@@ -0,0 +1,187 @@
+package formatter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// summaryRule frames section headings of the summary
+const summaryRule = "⎯⎯⎯⎯⎯⎯⎯"
+
+// stateCounts tallies packages or tests by state
+type stateCounts struct {
+	failed  int
+	passed  int
+	skipped int
+}
+
+// String renders the counts like "1 failed | 2 passed (3)"
+func (c stateCounts) String() string {
+	var parts []string
+	for _, count := range []struct {
+		n     int
+		label string
+	}{{c.failed, "failed"}, {c.passed, "passed"}, {c.skipped, "skipped"}} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.label))
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "none")
+	}
+	return fmt.Sprintf("%s (%d)", strings.Join(parts, " | "), c.failed+c.passed+c.skipped)
+}
+
+// FormatSummary renders the end-of-run summary, laid out like the Vitest
+// summary other TDD Guard reporters show: failing tests with their first
+// error line, build failures, tests flagged as slow, the slowest tests and
+// the counts of packages and tests. Returns an empty string when no test ran.
+func FormatSummary(result *transformer.TestResult, elapsed time.Duration, slowest int) string {
+	var packages, tests stateCounts
+	var failures, buildFailures, slow, timed []transformer.Test
+
+	for _, module := range sortedModules(result.TestModules) {
+		state := "skipped"
+		for _, test := range module.Tests {
+			switch {
+			case test.Name == parser.CompilationErrorTest && test.State == "failed":
+				buildFailures = append(buildFailures, test)
+				state = "failed"
+				continue
+			case test.State == "failed":
+				tests.failed++
+				failures = append(failures, test)
+				state = "failed"
+			case test.State == "passed":
+				tests.passed++
+				if state == "skipped" {
+					state = "passed"
+				}
+			default:
+				tests.skipped++
+			}
+			if test.Slow {
+				slow = append(slow, test)
+			}
+			if test.Duration > 0 {
+				timed = append(timed, test)
+			}
+		}
+		switch state {
+		case "failed":
+			packages.failed++
+		case "passed":
+			packages.passed++
+		default:
+			packages.skipped++
+		}
+	}
+	if len(failures) == 0 && len(buildFailures) == 0 && tests == (stateCounts{}) {
+		return ""
+	}
+
+	var lines []string
+	if len(failures) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%s Failed Tests %d %s", summaryRule, len(failures), summaryRule))
+		for _, test := range failures {
+			lines = append(lines, summaryFailure(test)...)
+		}
+	}
+	if len(buildFailures) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%s Build Failures %d %s", summaryRule, len(buildFailures), summaryRule))
+		for _, test := range buildFailures {
+			lines = append(lines, summaryFailure(test)...)
+		}
+	}
+	if len(slow) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%s Slow Tests %d %s", summaryRule, len(slow), summaryRule))
+		for _, test := range slowestTests(slow, len(slow)) {
+			lines = append(lines, summaryTiming(test))
+		}
+	}
+	if slowTests := slowestTests(timed, slowest); len(slowTests) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%s Slowest Tests %s", summaryRule, summaryRule))
+		for _, test := range slowTests {
+			lines = append(lines, summaryTiming(test))
+		}
+	}
+
+	lines = append(lines, "",
+		fmt.Sprintf(" Packages  %s", packages),
+		fmt.Sprintf("    Tests  %s", tests),
+		fmt.Sprintf(" Duration  %.2fs", elapsed.Seconds()),
+	)
+	return strings.Join(lines, "\n")
+}
+
+// summaryFailure renders a failed test with its location and first error line
+func summaryFailure(test transformer.Test) []string {
+	name := test.FullName
+	if test.Name == parser.CompilationErrorTest {
+		name = strings.TrimSuffix(test.FullName, "/"+parser.CompilationErrorTest)
+	}
+	header := " FAIL  " + name
+	if test.File != "" {
+		header += fmt.Sprintf(" (%s:%d)", test.File, test.Line)
+	}
+
+	lines := []string{header}
+	if len(test.Errors) > 0 {
+		if line := firstErrorLine(test.Errors[0].Message); line != "" {
+			lines = append(lines, "       "+line)
+		}
+	}
+	return lines
+}
+
+// summaryTiming renders a test with the time it ran, adding the wall time of
+// parallel tests that spent time paused
+func summaryTiming(test transformer.Test) string {
+	line := fmt.Sprintf(" %8.3fs  %s", test.Duration.Seconds(), test.FullName)
+	if test.Parallel && test.WallTime > test.Duration {
+		line += fmt.Sprintf(" (parallel, %.3fs wall)", test.WallTime.Seconds())
+	}
+	return line
+}
+
+// firstErrorLine returns the first non-empty line of an error message
+func firstErrorLine(message string) string {
+	for _, line := range strings.Split(message, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// slowestTests returns up to n tests ordered from slowest
+func slowestTests(tests []transformer.Test, n int) []transformer.Test {
+	if n <= 0 {
+		return nil
+	}
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].Duration > tests[j].Duration
+	})
+	return tests[:min(n, len(tests))]
+}
+
+// sortedModules returns the modules sorted by ID for stable output
+func sortedModules(modules []transformer.TestModule) []transformer.TestModule {
+	sorted := append([]transformer.TestModule{}, modules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ModuleID < sorted[j].ModuleID
+	})
+	for i := range sorted {
+		tests := append([]transformer.Test{}, sorted[i].Tests...)
+		sort.SliceStable(tests, func(a, b int) bool {
+			return tests[a].Name < tests[b].Name
+		})
+		sorted[i].Tests = tests
+	}
+	return sorted
+}
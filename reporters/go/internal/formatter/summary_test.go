@@ -0,0 +1,115 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestFormatSummary(t *testing.T) {
+	result := &transformer.TestResult{
+		TestModules: []transformer.TestModule{
+			{ModuleID: "calc", Tests: []transformer.Test{
+				{Name: "TestAdd", FullName: "example.com/calc/TestAdd", State: "passed", Duration: 10 * time.Millisecond},
+				{Name: "TestSlow", FullName: "example.com/calc/TestSlow", State: "passed", Duration: 1250 * time.Millisecond},
+				{
+					Name:     "TestDivide",
+					FullName: "example.com/calc/TestDivide",
+					State:    "failed",
+					File:     "calc/calc_test.go",
+					Line:     12,
+					Errors:   []transformer.TestError{{Message: "\ncalc_test.go:14: expected 2, got 3\nmore details"}},
+				},
+			}},
+			{ModuleID: "net", Tests: []transformer.Test{
+				{Name: "TestDial", FullName: "example.com/net/TestDial", State: "skipped"},
+			}},
+			{ModuleID: "broken", Tests: []transformer.Test{
+				{
+					Name:     "CompilationError",
+					FullName: "example.com/broken/CompilationError",
+					State:    "failed",
+					Errors:   []transformer.TestError{{Message: "./broken.go:3:1: syntax error"}},
+				},
+			}},
+		},
+	}
+
+	t.Run("renders failures, build failures, slowest tests and counts", func(t *testing.T) {
+		summary := FormatSummary(result, 1530*time.Millisecond, 1)
+
+		expected := strings.Join([]string{
+			"",
+			"⎯⎯⎯⎯⎯⎯⎯ Failed Tests 1 ⎯⎯⎯⎯⎯⎯⎯",
+			" FAIL  example.com/calc/TestDivide (calc/calc_test.go:12)",
+			"       calc_test.go:14: expected 2, got 3",
+			"",
+			"⎯⎯⎯⎯⎯⎯⎯ Build Failures 1 ⎯⎯⎯⎯⎯⎯⎯",
+			" FAIL  example.com/broken",
+			"       ./broken.go:3:1: syntax error",
+			"",
+			"⎯⎯⎯⎯⎯⎯⎯ Slowest Tests ⎯⎯⎯⎯⎯⎯⎯",
+			"    1.250s  example.com/calc/TestSlow",
+			"",
+			" Packages  2 failed | 1 skipped (3)",
+			"    Tests  1 failed | 2 passed | 1 skipped (4)",
+			" Duration  1.53s",
+		}, "\n")
+		if summary != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, summary)
+		}
+	})
+
+	t.Run("lists tests flagged as slow", func(t *testing.T) {
+		flagged := &transformer.TestResult{TestModules: []transformer.TestModule{
+			{ModuleID: "calc", Tests: []transformer.Test{
+				{Name: "TestA", FullName: "example.com/calc/TestA", State: "passed", Duration: time.Second, Slow: true},
+				{Name: "TestB", FullName: "example.com/calc/TestB", State: "passed", Duration: 2 * time.Second, Slow: true},
+			}},
+		}}
+
+		summary := FormatSummary(flagged, time.Second, 0)
+
+		expected := "⎯⎯⎯⎯⎯⎯⎯ Slow Tests 2 ⎯⎯⎯⎯⎯⎯⎯\n    2.000s  example.com/calc/TestB\n    1.000s  example.com/calc/TestA\n"
+		if !strings.Contains(summary, expected) {
+			t.Errorf("Expected slow tests section, got:\n%s", summary)
+		}
+	})
+
+	t.Run("adds the wall time of paused parallel tests", func(t *testing.T) {
+		parallel := &transformer.TestResult{TestModules: []transformer.TestModule{
+			{ModuleID: "calc", Tests: []transformer.Test{
+				{Name: "TestA", FullName: "example.com/calc/TestA", State: "passed", Duration: time.Second, WallTime: 3 * time.Second, Parallel: true},
+			}},
+		}}
+
+		summary := FormatSummary(parallel, 3*time.Second, 5)
+
+		if !strings.Contains(summary, "    1.000s  example.com/calc/TestA (parallel, 3.000s wall)\n") {
+			t.Errorf("Expected active and wall time, got:\n%s", summary)
+		}
+	})
+
+	t.Run("omits sections without entries", func(t *testing.T) {
+		passing := &transformer.TestResult{TestModules: []transformer.TestModule{
+			{ModuleID: "calc", Tests: []transformer.Test{{Name: "TestAdd", FullName: "example.com/calc/TestAdd", State: "passed"}}},
+		}}
+
+		summary := FormatSummary(passing, time.Second, 5)
+
+		expected := "\n Packages  1 passed (1)\n    Tests  1 passed (1)\n Duration  1.00s"
+		if summary != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, summary)
+		}
+	})
+
+	t.Run("returns nothing when no test ran", func(t *testing.T) {
+		empty := &transformer.TestResult{TestModules: []transformer.TestModule{{ModuleID: "calc"}}}
+
+		if summary := FormatSummary(empty, time.Second, 5); summary != "" {
+			t.Errorf("Expected no summary, got:\n%s", summary)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// FormatTransitions renders a short summary of what changed since the previous run.
+// Returns an empty string when nothing changed.
+func FormatTransitions(transitions *transformer.Transitions) string {
+	if transitions.IsEmpty() {
+		return ""
+	}
+
+	groups := []struct {
+		label string
+		tests []string
+	}{
+		{"newly failed", transitions.NewlyFailed},
+		{"newly passed", transitions.NewlyPassed},
+		{"newly skipped", transitions.NewlySkipped},
+		{"unskipped", transitions.Unskipped},
+		{"added", transitions.Added},
+		{"removed", transitions.Removed},
+	}
+
+	counts := []string{}
+	details := []string{}
+	for _, group := range groups {
+		if len(group.tests) == 0 {
+			continue
+		}
+		counts = append(counts, fmt.Sprintf("%d %s", len(group.tests), group.label))
+		for _, test := range group.tests {
+			details = append(details, fmt.Sprintf("  %s: %s", group.label, test))
+		}
+	}
+
+	lines := append([]string{"Since last run: " + strings.Join(counts, ", ")}, details...)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,36 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestFormatTransitions(t *testing.T) {
+	t.Run("returns empty string for nil transitions", func(t *testing.T) {
+		if result := FormatTransitions(nil); result != "" {
+			t.Fatalf("Expected empty string, got '%s'", result)
+		}
+	})
+
+	t.Run("returns empty string when nothing changed", func(t *testing.T) {
+		if result := FormatTransitions(&transformer.Transitions{}); result != "" {
+			t.Fatalf("Expected empty string, got '%s'", result)
+		}
+	})
+
+	t.Run("summarizes counts and lists tests", func(t *testing.T) {
+		result := FormatTransitions(&transformer.Transitions{
+			NewlyFailed: []string{"example.com/pkg/TestA"},
+			Added:       []string{"example.com/pkg/TestB", "example.com/pkg/TestC"},
+		})
+
+		expected := "Since last run: 1 newly failed, 2 added\n" +
+			"  newly failed: example.com/pkg/TestA\n" +
+			"  added: example.com/pkg/TestB\n" +
+			"  added: example.com/pkg/TestC"
+		if result != expected {
+			t.Fatalf("Expected:\n%s\nGot:\n%s", expected, result)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package merger
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/slow"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// Merger combines the results of a partial run with previously saved results.
+// Packages and tests present in the current run replace their previous entries,
+// everything else is kept until it becomes older than the configured maximum age.
+type Merger struct {
+	maxAge  time.Duration
+	savedAt time.Time
+}
+
+// NewMerger creates a merger that drops entries older than maxAge.
+// A zero maxAge keeps previous entries regardless of their age.
+func NewMerger(maxAge time.Duration) *Merger {
+	return &Merger{maxAge: maxAge}
+}
+
+// SetSavedAt dates previous results saved by runs that did not merge, which
+// carry no runs of their own. Without it they are dated like the current run.
+func (m *Merger) SetSavedAt(savedAt time.Time) {
+	m.savedAt = savedAt
+}
+
+// Merge returns the combined result with every test marked with the run it came from
+func (m *Merger) Merge(previous, current *transformer.TestResult, run transformer.Run) *transformer.TestResult {
+	markRun(current, run.ID)
+	if previous != nil {
+		adoptUnmarked(previous, m.legacyRun(previous, run))
+	}
+
+	runs := m.freshRuns(previous, run)
+	merged := &transformer.TestResult{TestModules: []transformer.TestModule{}}
+	currentModules := indexModules(current)
+
+	if previous != nil {
+		for _, module := range previous.TestModules {
+			if latest, exists := currentModules[module.ModuleID]; exists {
+				module = mergeModule(module, latest, runs)
+				delete(currentModules, module.ModuleID)
+			} else {
+				module.Tests = keepFresh(module.Tests, runs)
+			}
+			if len(module.Tests) > 0 {
+				merged.TestModules = append(merged.TestModules, module)
+			}
+		}
+	}
+
+	// Remaining modules only appeared in the current run, keep their order
+	for _, module := range current.TestModules {
+		if _, isNew := currentModules[module.ModuleID]; isNew {
+			merged.TestModules = append(merged.TestModules, module)
+		}
+	}
+
+	merged.Runs = referencedRuns(merged, runs, run)
+	merged.NotRun = current.NotRun
+	merged.Warnings = current.Warnings
+	merged.Reason = reason(merged)
+	return merged
+}
+
+// freshRuns indexes the previous runs that are still within the maximum age
+func (m *Merger) freshRuns(previous *transformer.TestResult, current transformer.Run) map[string]transformer.Run {
+	runs := map[string]transformer.Run{current.ID: current}
+	if previous == nil {
+		return runs
+	}
+
+	for _, run := range previous.Runs {
+		if m.maxAge > 0 && current.StartedAt.Sub(run.StartedAt) > m.maxAge {
+			continue
+		}
+		runs[run.ID] = run
+	}
+	return runs
+}
+
+// legacyRun returns the run previous tests without a run are aged by: the
+// oldest previous run, or a run started when the previous results were saved
+func (m *Merger) legacyRun(previous *transformer.TestResult, current transformer.Run) transformer.Run {
+	if len(previous.Runs) > 0 {
+		oldest := previous.Runs[0]
+		for _, run := range previous.Runs[1:] {
+			if run.StartedAt.Before(oldest.StartedAt) {
+				oldest = run
+			}
+		}
+		return oldest
+	}
+	if m.savedAt.IsZero() {
+		return transformer.NewRun(current.StartedAt)
+	}
+	return transformer.NewRun(m.savedAt)
+}
+
+// adoptUnmarked marks tests without a run, saved by a run that did not merge
+// or an older reporter, with the run so the maximum age applies to them
+func adoptUnmarked(previous *transformer.TestResult, run transformer.Run) {
+	adopted := false
+	for i := range previous.TestModules {
+		tests := previous.TestModules[i].Tests
+		for j := range tests {
+			if tests[j].RunID == "" {
+				tests[j].RunID = run.ID
+				adopted = true
+			}
+		}
+	}
+	if adopted && !slices.ContainsFunc(previous.Runs, func(r transformer.Run) bool { return r.ID == run.ID }) {
+		previous.Runs = append(previous.Runs, run)
+	}
+}
+
+// mergeModule updates the previous module with the tests of the current run.
+// A compilation error replaces the whole module, and a module that compiles
+// again no longer reports its previous compilation error. A package is only
+// over its slow budget if the current run says so.
+func mergeModule(previous, current transformer.TestModule, runs map[string]transformer.Run) transformer.TestModule {
+	if hasTest(current, parser.CompilationErrorTest) {
+		return current
+	}
+
+	latest := make(map[string]transformer.Test)
+	for _, test := range current.Tests {
+		latest[test.FullName] = test
+	}
+
+	tests := []transformer.Test{}
+	for _, test := range keepFresh(previous.Tests, runs) {
+		if test.Name == parser.CompilationErrorTest || test.Name == slow.PackageTest {
+			continue
+		}
+		if updated, exists := latest[test.FullName]; exists {
+			test = updated
+			delete(latest, test.FullName)
+		}
+		tests = append(tests, test)
+	}
+
+	for _, test := range current.Tests {
+		if _, isNew := latest[test.FullName]; isNew {
+			tests = append(tests, test)
+		}
+	}
+
+	previous.Tests = tests
+	previous.Cached = current.Cached
+	return previous
+}
+
+// keepFresh drops tests whose run is unknown or older than the maximum age
+func keepFresh(tests []transformer.Test, runs map[string]transformer.Run) []transformer.Test {
+	fresh := []transformer.Test{}
+	for _, test := range tests {
+		if _, exists := runs[test.RunID]; exists {
+			fresh = append(fresh, test)
+		}
+	}
+	return fresh
+}
+
+// referencedRuns lists the runs still referenced by merged tests, oldest first
+func referencedRuns(merged *transformer.TestResult, runs map[string]transformer.Run, current transformer.Run) []transformer.Run {
+	used := map[string]bool{current.ID: true}
+	for _, module := range merged.TestModules {
+		for _, test := range module.Tests {
+			used[test.RunID] = true
+		}
+	}
+
+	result := []transformer.Run{}
+	for id := range used {
+		result = append(result, runs[id])
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result
+}
+
+// markRun sets the run ID on every test of the result
+func markRun(result *transformer.TestResult, runID string) {
+	for i := range result.TestModules {
+		tests := result.TestModules[i].Tests
+		for j := range tests {
+			tests[j].RunID = runID
+		}
+	}
+}
+
+// indexModules maps each module ID to its module
+func indexModules(result *transformer.TestResult) map[string]transformer.TestModule {
+	modules := make(map[string]transformer.TestModule)
+	for _, module := range result.TestModules {
+		modules[module.ModuleID] = module
+	}
+	return modules
+}
+
+// hasTest checks if the module contains a test with the given name
+func hasTest(module transformer.TestModule, name string) bool {
+	for _, test := range module.Tests {
+		if test.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// reason reports failed when any merged test failed
+func reason(result *transformer.TestResult) string {
+	for _, module := range result.TestModules {
+		for _, test := range module.Tests {
+			if test.State == "failed" {
+				return "failed"
+			}
+		}
+	}
+	return "passed"
+}
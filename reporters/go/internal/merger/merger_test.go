@@ -0,0 +1,259 @@
+package merger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+const (
+	pkgA = "example.com/a"
+	pkgB = "example.com/b"
+	pkgC = "example.com/c"
+)
+
+var startedAt = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMerger(t *testing.T) {
+	t.Run("Creation", func(t *testing.T) {
+		if NewMerger(time.Hour) == nil {
+			t.Fatal("Expected merger to be created")
+		}
+	})
+
+	t.Run("Without previous result", func(t *testing.T) {
+		run := transformer.NewRun(startedAt)
+		merged := NewMerger(0).Merge(nil, result(module(pkgA, test(pkgA, "TestOne", "passed"))), run)
+
+		t.Run("keeps current tests", func(t *testing.T) {
+			assertTests(t, merged, pkgA, "TestOne")
+		})
+
+		t.Run("marks tests with the run", func(t *testing.T) {
+			if got := findTest(t, merged, pkgA, "TestOne").RunID; got != run.ID {
+				t.Errorf("Expected run ID %q, got %q", run.ID, got)
+			}
+		})
+
+		t.Run("records the run", func(t *testing.T) {
+			if len(merged.Runs) != 1 || merged.Runs[0].ID != run.ID {
+				t.Errorf("Expected runs to contain %q, got %+v", run.ID, merged.Runs)
+			}
+		})
+	})
+
+	t.Run("Partial runs", func(t *testing.T) {
+		first := transformer.NewRun(startedAt)
+		second := transformer.NewRun(startedAt.Add(time.Minute))
+		merger := NewMerger(0)
+
+		previous := merger.Merge(nil, result(
+			module(pkgA, test(pkgA, "TestOne", "passed"), test(pkgA, "TestTwo", "passed")),
+			module(pkgB, test(pkgB, "TestThree", "failed")),
+		), first)
+		merged := merger.Merge(previous, result(module(pkgA, test(pkgA, "TestOne", "failed"))), second)
+
+		t.Run("keeps packages that did not run", func(t *testing.T) {
+			assertTests(t, merged, pkgB, "TestThree")
+		})
+
+		t.Run("keeps tests that did not run", func(t *testing.T) {
+			assertTests(t, merged, pkgA, "TestOne", "TestTwo")
+		})
+
+		t.Run("updates tests that ran", func(t *testing.T) {
+			if got := findTest(t, merged, pkgA, "TestOne").State; got != "failed" {
+				t.Errorf("Expected updated state failed, got %q", got)
+			}
+		})
+
+		t.Run("marks each test with the run it came from", func(t *testing.T) {
+			if got := findTest(t, merged, pkgA, "TestOne").RunID; got != second.ID {
+				t.Errorf("Expected run ID %q, got %q", second.ID, got)
+			}
+			if got := findTest(t, merged, pkgA, "TestTwo").RunID; got != first.ID {
+				t.Errorf("Expected run ID %q, got %q", first.ID, got)
+			}
+		})
+
+		t.Run("lists both runs oldest first", func(t *testing.T) {
+			if len(merged.Runs) != 2 || merged.Runs[0].ID != first.ID || merged.Runs[1].ID != second.ID {
+				t.Errorf("Expected runs [%s %s], got %+v", first.ID, second.ID, merged.Runs)
+			}
+		})
+
+		t.Run("computes reason across merged tests", func(t *testing.T) {
+			if merged.Reason != "failed" {
+				t.Errorf("Expected reason failed, got %q", merged.Reason)
+			}
+		})
+
+		t.Run("records packages the current run did not run", func(t *testing.T) {
+			current := result(module(pkgA, test(pkgA, "TestOne", "passed")))
+			current.NotRun = []string{pkgB}
+			merged := merger.Merge(previous, current, second)
+			if len(merged.NotRun) != 1 || merged.NotRun[0] != pkgB {
+				t.Errorf("Expected not run packages [%s], got %v", pkgB, merged.NotRun)
+			}
+		})
+		t.Run("takes cache state and warnings from the current run", func(t *testing.T) {
+			cached := module(pkgA, test(pkgA, "TestOne", "passed"))
+			cached.Cached = true
+			current := result(cached)
+			current.Warnings = []string{"stale"}
+			merged := merger.Merge(previous, current, second)
+
+			if !merged.TestModules[0].Cached || len(merged.Warnings) != 1 {
+				t.Errorf("Expected cached module and warning, got %+v", merged)
+			}
+		})
+	})
+
+	t.Run("Stale entries", func(t *testing.T) {
+		first := transformer.NewRun(startedAt)
+		later := transformer.NewRun(startedAt.Add(2 * time.Hour))
+		merger := NewMerger(time.Hour)
+
+		previous := merger.Merge(nil, result(module(pkgB, test(pkgB, "TestOld", "failed"))), first)
+		merged := merger.Merge(previous, result(module(pkgA, test(pkgA, "TestNew", "passed"))), later)
+
+		t.Run("drops entries older than the maximum age", func(t *testing.T) {
+			for _, module := range merged.TestModules {
+				if module.ModuleID == pkgB {
+					t.Fatalf("Expected stale module to be dropped, got %+v", module)
+				}
+			}
+		})
+
+		t.Run("drops stale runs", func(t *testing.T) {
+			if len(merged.Runs) != 1 || merged.Runs[0].ID != later.ID {
+				t.Errorf("Expected only the latest run, got %+v", merged.Runs)
+			}
+		})
+
+		t.Run("keeps tests saved without merging until they are stale", func(t *testing.T) {
+			merger := NewMerger(time.Hour)
+			merger.SetSavedAt(startedAt.Add(90 * time.Minute))
+			unmarked := result(module(pkgB, test(pkgB, "TestUnmarked", "passed")))
+
+			merged := merger.Merge(unmarked, result(module(pkgA, test(pkgA, "TestNew", "passed"))), later)
+
+			if len(merged.TestModules) != 2 {
+				t.Fatalf("Expected both modules, got %+v", merged.TestModules)
+			}
+			saved := transformer.NewRun(startedAt.Add(90 * time.Minute))
+			if len(merged.Runs) != 2 || merged.Runs[0].ID != saved.ID {
+				t.Errorf("Expected a run dated when the results were saved, got %+v", merged.Runs)
+			}
+
+			merged = merger.Merge(merged, result(module(pkgA, test(pkgA, "TestNew", "passed"))), transformer.NewRun(startedAt.Add(3*time.Hour)))
+
+			if len(merged.TestModules) != 1 {
+				t.Errorf("Expected tests saved without merging to expire, got %+v", merged.TestModules)
+			}
+		})
+
+		t.Run("ages tests without a run by the oldest run", func(t *testing.T) {
+			previous := merger.Merge(nil, result(module(pkgB, test(pkgB, "TestOld", "failed"))), first)
+			previous.TestModules = append(previous.TestModules, module(pkgC, test(pkgC, "TestLegacy", "passed")))
+
+			merged := merger.Merge(previous, result(module(pkgA, test(pkgA, "TestNew", "passed"))), later)
+
+			if len(merged.TestModules) != 1 || merged.TestModules[0].ModuleID != pkgA {
+				t.Errorf("Expected only the current module, got %+v", merged.TestModules)
+			}
+		})
+
+		t.Run("drops tests of unknown runs", func(t *testing.T) {
+			orphaned := test(pkgB, "TestOrphaned", "passed")
+			orphaned.RunID = "unknown"
+			merged := merger.Merge(result(module(pkgB, orphaned)), result(module(pkgA, test(pkgA, "TestNew", "passed"))), later)
+
+			if len(merged.TestModules) != 1 {
+				t.Fatalf("Expected only the current module, got %+v", merged.TestModules)
+			}
+		})
+	})
+
+	t.Run("Compilation errors", func(t *testing.T) {
+		first := transformer.NewRun(startedAt)
+		second := transformer.NewRun(startedAt.Add(time.Minute))
+		merger := NewMerger(0)
+
+		t.Run("replace the whole module", func(t *testing.T) {
+			previous := merger.Merge(nil, result(module(pkgA, test(pkgA, "TestOne", "passed"))), first)
+			merged := merger.Merge(previous, result(module(pkgA, test(pkgA, "CompilationError", "failed"))), second)
+
+			assertTests(t, merged, pkgA, "CompilationError")
+		})
+
+		t.Run("are cleared once the module compiles again", func(t *testing.T) {
+			previous := merger.Merge(nil, result(module(pkgA, test(pkgA, "CompilationError", "failed"))), first)
+			merged := merger.Merge(previous, result(module(pkgA, test(pkgA, "TestOne", "passed"))), second)
+
+			assertTests(t, merged, pkgA, "TestOne")
+		})
+	})
+
+	t.Run("clears a slow package once it runs within budget", func(t *testing.T) {
+		merger := NewMerger(0)
+		previous := merger.Merge(nil, result(module(pkgA,
+			test(pkgA, "TestOne", "passed"),
+			test(pkgA, "SlowPackage", "failed"),
+		)), transformer.NewRun(startedAt))
+
+		merged := merger.Merge(previous, result(module(pkgA, test(pkgA, "TestOne", "passed"))), transformer.NewRun(startedAt.Add(time.Minute)))
+
+		assertTests(t, merged, pkgA, "TestOne")
+	})
+}
+
+// Helper functions
+
+func result(modules ...transformer.TestModule) *transformer.TestResult {
+	return &transformer.TestResult{TestModules: modules}
+}
+
+func module(id string, tests ...transformer.Test) transformer.TestModule {
+	return transformer.TestModule{ModuleID: id, Tests: tests}
+}
+
+func test(pkg, name, state string) transformer.Test {
+	return transformer.Test{Name: name, FullName: pkg + "/" + name, State: state}
+}
+
+func findTest(t *testing.T, result *transformer.TestResult, pkg, name string) transformer.Test {
+	t.Helper()
+	for _, module := range result.TestModules {
+		if module.ModuleID != pkg {
+			continue
+		}
+		for _, test := range module.Tests {
+			if test.Name == name {
+				return test
+			}
+		}
+	}
+	t.Fatalf("Test %s/%s not found", pkg, name)
+	return transformer.Test{}
+}
+
+func assertTests(t *testing.T, result *transformer.TestResult, pkg string, names ...string) {
+	t.Helper()
+	for _, module := range result.TestModules {
+		if module.ModuleID != pkg {
+			continue
+		}
+		if len(module.Tests) != len(names) {
+			t.Fatalf("Expected %d tests in %s, got %+v", len(names), pkg, module.Tests)
+		}
+		for i, name := range names {
+			if module.Tests[i].Name != name {
+				t.Errorf("Expected test %d to be %s, got %s", i, name, module.Tests[i].Name)
+			}
+		}
+		return
+	}
+	t.Fatalf("Module %s not found", pkg)
+}
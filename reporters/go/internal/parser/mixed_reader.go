@@ -30,8 +30,8 @@ func NewMixedReader(reader io.Reader) *MixedReader {
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		// Try to parse as JSON
-		if isJSON(line) {
+		// Try to parse as a JSON event
+		if _, ok := DecodeEvent(line); ok {
 			mr.JSONLines = append(mr.JSONLines, line)
 			continue
 		}
@@ -69,10 +69,15 @@ func NewMixedReader(reader io.Reader) *MixedReader {
 	return mr
 }
 
-// isJSON checks if a line is valid JSON
-func isJSON(line string) bool {
+// DecodeEvent decodes a line of go test -json output. Other JSON, like
+// null or a JSON log line printed by a test, is not an event since it
+// has no Action.
+func DecodeEvent(line string) (TestEvent, bool) {
 	var event TestEvent
-	return json.Unmarshal([]byte(line), &event) == nil
+	if err := json.Unmarshal([]byte(line), &event); err != nil || event.Action == "" {
+		return TestEvent{}, false
+	}
+	return event, true
 }
 
 // isErrorHeader checks if line starts with # (compilation error header)
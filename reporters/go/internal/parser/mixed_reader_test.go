@@ -67,6 +67,25 @@ func TestMixedReader_SeparatesJSONFromNonJSON(t *testing.T) {
 	}
 }
 
+func TestMixedReader_KeepsJSONWithoutActionAsText(t *testing.T) {
+	input := strings.Join([]string{
+		"=== RUN   TestLog",
+		"null",
+		"{}",
+		`{"level":"info","msg":"started"}`,
+		"--- PASS: TestLog (0.00s)",
+	}, "\n")
+
+	mr := NewMixedReader(strings.NewReader(input))
+
+	if len(mr.JSONLines) != 0 {
+		t.Errorf("Expected no JSON lines, got %q", mr.JSONLines)
+	}
+	if len(mr.NonJSONLines) != 5 {
+		t.Errorf("Expected every line as text, got %q", mr.NonJSONLines)
+	}
+}
+
 func TestMixedReader_DetectsCompilationError(t *testing.T) {
 	input := `# command-line-arguments
 single_import_error_test.go:5:2: no required module provides package github.com/non-existent/module
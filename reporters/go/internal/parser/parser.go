@@ -5,17 +5,19 @@ import (
 	"encoding/json"
 	"io"
 	"strings"
+	"time"
 )
 
 // TestEvent represents a test event from go test -json
 type TestEvent struct {
-	Action      string  `json:"Action"`
-	Package     string  `json:"Package"`
-	Test        string  `json:"Test"`
-	Elapsed     float64 `json:"Elapsed"`
-	Output      string  `json:"Output"`
-	ImportPath  string  `json:"ImportPath"`
-	FailedBuild string  `json:"FailedBuild"`
+	Time        time.Time `json:"Time"`
+	Action      string    `json:"Action"`
+	Package     string    `json:"Package"`
+	Test        string    `json:"Test"`
+	Elapsed     float64   `json:"Elapsed"`
+	Output      string    `json:"Output"`
+	ImportPath  string    `json:"ImportPath"`
+	FailedBuild string    `json:"FailedBuild"`
 }
 
 // TestState represents the state of a test
@@ -27,6 +29,14 @@ const (
 	StateSkipped TestState = "skipped"
 )
 
+// CompilationErrorTest is the synthetic test recorded for a package that
+// failed to build
+const CompilationErrorTest = "CompilationError"
+
+// DefaultPackage names the package of events without one until SetPackage
+// names another, as go does for tests of files listed on the command line
+const DefaultPackage = "command-line-arguments"
+
 // PackageResults holds test results for a package
 type PackageResults map[string]TestState
 
@@ -37,8 +47,41 @@ type Results map[string]PackageResults
 type Parser struct {
 	results       Results
 	errorOutputs  map[string]string
-	testOutputs   map[string]map[string]string // Track test output content
-	buildFailures map[string]string            // Track build failures and their output
+	testOutputs   map[string]map[string]string     // Track test output content
+	buildFailures map[string]string                // Track build failures and their output
+	elapsed       map[string]map[string]float64    // Seconds reported when each test ended
+	pkgElapsed    map[string]float64               // Seconds reported when each package ended
+	firstEvent    time.Time                        // Time of the earliest timestamped event
+	lastEvent     time.Time                        // Time of the latest timestamped event
+	lifecycles    map[string]map[string]*lifecycle // Run, pause and cont times of each test
+	currentTest   map[string]string                // Test named by the last === marker of each package
+	cached        map[string]bool                  // Packages whose results go test took from its cache
+	pkg           string                           // Package of events without one
+	unnamed       bool                             // Whether any event lacked a package
+}
+
+// Timing describes how long a test ran. Tests calling t.Parallel pause
+// until their parent finishes, so their wall time covers the pause while
+// their active time only counts the time they were running.
+type Timing struct {
+	Wall     time.Duration // From run to the end of the test
+	Active   time.Duration // Time between run or cont and the next pause or end
+	Parallel bool          // Whether the test paused for t.Parallel
+}
+
+// lifecycle follows a test through its run, pause and cont events
+type lifecycle struct {
+	timing  Timing
+	started time.Time // When the test ran
+	resumed time.Time // When the test last ran or continued, zero while paused
+}
+
+// pause adds the time since the test last resumed to its active time
+func (l *lifecycle) pause(at time.Time) {
+	if !l.resumed.IsZero() {
+		l.timing.Active += at.Sub(l.resumed)
+		l.resumed = time.Time{}
+	}
 }
 
 // NewParser creates a new parser
@@ -48,9 +91,33 @@ func NewParser() *Parser {
 		errorOutputs:  make(map[string]string),
 		testOutputs:   make(map[string]map[string]string),
 		buildFailures: make(map[string]string),
+		elapsed:       make(map[string]map[string]float64),
+		pkgElapsed:    make(map[string]float64),
+		lifecycles:    make(map[string]map[string]*lifecycle),
+		currentTest:   make(map[string]string),
+		cached:        make(map[string]bool),
+		pkg:           DefaultPackage,
 	}
 }
 
+// SetPackage names the package of events without one. go tool test2json
+// only adds packages when given -p, so events of a test binary it ran
+// are attributed to this package instead of being dropped.
+func (p *Parser) SetPackage(pkg string) {
+	p.pkg = pkg
+}
+
+// Package returns the package of events without one
+func (p *Parser) Package() string {
+	return p.pkg
+}
+
+// HasUnnamedEvents reports whether any event lacked a package and was
+// attributed to the package set with SetPackage
+func (p *Parser) HasUnnamedEvents() bool {
+	return p.unnamed
+}
+
 // Parse reads from the provided reader
 func (p *Parser) Parse(reader io.Reader) error {
 	scanner := bufio.NewScanner(reader)
@@ -67,8 +134,15 @@ func (p *Parser) Parse(reader io.Reader) error {
 	return scanner.Err()
 }
 
+// Add processes a single test event
+func (p *Parser) Add(event TestEvent) {
+	p.processEvent(&event)
+}
+
 // processEvent handles a single test event
 func (p *Parser) processEvent(event *TestEvent) {
+	p.recordTime(event.Time)
+
 	// Handle build events (they have ImportPath instead of Package)
 	if event.ImportPath != "" && event.Action == "build-output" {
 		p.buildFailures[event.ImportPath] += event.Output
@@ -78,13 +152,18 @@ func (p *Parser) processEvent(event *TestEvent) {
 	// Handle build failure events
 	if event.Action == "fail" && event.FailedBuild != "" {
 		p.ensurePackageExists(event.Package)
-		p.results[event.Package]["CompilationError"] = StateFailed
+		p.results[event.Package][CompilationErrorTest] = StateFailed
 		return
 	}
 
-	// Skip events without package
+	// Events of a test binary run by test2json without -p have no package,
+	// while build events of go test name theirs with ImportPath
 	if event.Package == "" {
-		return
+		if event.ImportPath != "" || event.Action == "" {
+			return
+		}
+		event.Package = p.pkg
+		p.unnamed = true
 	}
 
 	p.ensurePackageExists(event.Package)
@@ -97,6 +176,19 @@ func (p *Parser) processEvent(event *TestEvent) {
 	p.processTestEvent(event)
 }
 
+// recordTime widens the span of time covered by the events
+func (p *Parser) recordTime(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	if p.firstEvent.IsZero() || t.Before(p.firstEvent) {
+		p.firstEvent = t
+	}
+	if t.After(p.lastEvent) {
+		p.lastEvent = t
+	}
+}
+
 // ensurePackageExists creates package maps if they don't exist
 func (p *Parser) ensurePackageExists(pkg string) {
 	if p.results[pkg] == nil {
@@ -107,35 +199,97 @@ func (p *Parser) ensurePackageExists(pkg string) {
 // processPackageEvent handles package-level events (no test name)
 func (p *Parser) processPackageEvent(event *TestEvent) {
 	if event.Action == "output" {
+		// Log lines of a parallel test may reach the package stream when
+		// go test interleaves them; they belong to the test last named
+		if test := p.currentTest[event.Package]; test != "" && strings.HasPrefix(event.Output, "    ") {
+			p.captureTestOutput(&TestEvent{Package: event.Package, Test: test, Output: event.Output})
+			return
+		}
+		if isCachedResult(event.Output) {
+			p.cached[event.Package] = true
+		}
 		p.errorOutputs[event.Package] += event.Output
 	}
+	if event.Action == "pass" || event.Action == "fail" || event.Action == "skip" {
+		p.pkgElapsed[event.Package] = event.Elapsed
+	}
 	// Handle package-level fail (build failure without FailedBuild flag)
 	if event.Action == "fail" {
 		// Package failed but has no tests - this is a build failure
 		if len(p.results[event.Package]) == 0 {
-			p.results[event.Package]["CompilationError"] = StateFailed
+			p.results[event.Package][CompilationErrorTest] = StateFailed
 		}
 	}
 }
 
+// isCachedResult checks if output is the line of a package whose result
+// go test reused from its cache, like "ok  \texample.com/pkg\t(cached)"
+func isCachedResult(output string) bool {
+	return strings.HasPrefix(output, "ok  \t") && strings.Contains(output, "\t(cached)")
+}
+
 // processTestEvent handles test-specific events
 func (p *Parser) processTestEvent(event *TestEvent) {
 	switch event.Action {
 	case "output":
 		p.captureTestOutput(event)
+	case "run", "pause", "cont":
+		p.recordLifecycle(event)
 	case "pass", "fail", "skip":
+		p.recordLifecycle(event)
 		p.recordTestState(event)
 	}
 }
 
+// recordLifecycle tracks the run, pause, cont and end of a test to measure
+// its active and wall time. Events without timestamps are ignored.
+func (p *Parser) recordLifecycle(event *TestEvent) {
+	if event.Time.IsZero() {
+		return
+	}
+	if p.lifecycles[event.Package] == nil {
+		p.lifecycles[event.Package] = make(map[string]*lifecycle)
+	}
+	l := p.lifecycles[event.Package][event.Test]
+	if l == nil {
+		l = &lifecycle{}
+		p.lifecycles[event.Package][event.Test] = l
+	}
+
+	switch event.Action {
+	case "run":
+		l.started, l.resumed = event.Time, event.Time
+	case "cont":
+		l.resumed = event.Time
+	case "pause":
+		l.pause(event.Time)
+		l.timing.Parallel = true
+	default:
+		l.pause(event.Time)
+		if !l.started.IsZero() {
+			l.timing.Wall = event.Time.Sub(l.started)
+		}
+	}
+}
+
 // captureTestOutput captures output for a specific test
 func (p *Parser) captureTestOutput(event *TestEvent) {
 	if p.testOutputs[event.Package] == nil {
 		p.testOutputs[event.Package] = make(map[string]string)
 	}
 
-	// Skip RUN and FAIL lines
-	if strings.HasPrefix(event.Output, "=== RUN") || strings.HasPrefix(event.Output, "--- FAIL") {
+	// Skip RUN, PAUSE, CONT and NAME markers, remembering the test they name
+	// so interleaved package output can be attributed to it
+	if strings.HasPrefix(event.Output, "=== ") {
+		if strings.HasPrefix(event.Output, "=== PAUSE") {
+			p.clearCurrentTest(event.Package, event.Test)
+		} else {
+			p.currentTest[event.Package] = event.Test
+		}
+		return
+	}
+	// Skip FAIL lines, indented for subtests
+	if strings.HasPrefix(strings.TrimLeft(event.Output, " "), "--- FAIL") {
 		return
 	}
 
@@ -157,6 +311,18 @@ func (p *Parser) recordTestState(event *TestEvent) {
 	}
 
 	p.results[event.Package][event.Test] = state
+	p.clearCurrentTest(event.Package, event.Test)
+	if p.elapsed[event.Package] == nil {
+		p.elapsed[event.Package] = make(map[string]float64)
+	}
+	p.elapsed[event.Package][event.Test] = event.Elapsed
+}
+
+// clearCurrentTest stops attributing package output to a test that paused or ended
+func (p *Parser) clearCurrentTest(pkg, test string) {
+	if p.currentTest[pkg] == test {
+		delete(p.currentTest, pkg)
+	}
 }
 
 // ensureTestOutputExists ensures test output map exists for passed tests
@@ -218,7 +384,7 @@ func (p *Parser) GetErrorOutput(pkg string) string {
 // GetTestOutput returns captured output for a specific test
 func (p *Parser) GetTestOutput(pkg, test string) string {
 	// Special case for CompilationError - get build failure message
-	if test == "CompilationError" {
+	if test == CompilationErrorTest {
 		// Find the build failure for this package
 		for _, msg := range p.buildFailures {
 			// The build failure message contains the error details
@@ -243,3 +409,42 @@ func (p *Parser) GetTestOutput(pkg, test string) string {
 func (p *Parser) GetBuildFailure(importPath string) string {
 	return p.buildFailures[importPath]
 }
+
+// GetElapsed returns the seconds a test took, as reported when it ended
+func (p *Parser) GetElapsed(pkg, test string) float64 {
+	return p.elapsed[pkg][test]
+}
+
+// GetPackageElapsed returns the seconds a package's tests took
+func (p *Parser) GetPackageElapsed(pkg string) float64 {
+	return p.pkgElapsed[pkg]
+}
+
+// IsCached reports whether go test reused the package's cached result
+func (p *Parser) IsCached(pkg string) bool {
+	return p.cached[pkg]
+}
+
+// GetTiming returns the active and wall time of a test, measured from the
+// timestamps of its lifecycle events. It reports false for tests whose
+// events carried no timestamps.
+func (p *Parser) GetTiming(pkg, test string) (Timing, bool) {
+	l := p.lifecycles[pkg][test]
+	if l == nil || l.started.IsZero() {
+		return Timing{}, false
+	}
+	return l.timing, true
+}
+
+// GetWallTime returns the time from the first to the last event. Without
+// timestamps it falls back to the longest package run.
+func (p *Parser) GetWallTime() time.Duration {
+	if !p.firstEvent.IsZero() {
+		return p.lastEvent.Sub(p.firstEvent)
+	}
+	var longest float64
+	for _, elapsed := range p.pkgElapsed {
+		longest = max(longest, elapsed)
+	}
+	return time.Duration(longest * float64(time.Second))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 )
 
 // Test fixtures - common JSON events
@@ -344,6 +345,169 @@ func TestParser(t *testing.T) {
 		})
 	})
 
+	t.Run("Elapsed time", func(t *testing.T) {
+		parser := NewParser()
+		parser.Parse(strings.NewReader(strings.Join([]string{
+			`{"Action":"run","Package":"example.com/pkg","Test":"TestSlow"}`,
+			`{"Action":"pass","Package":"example.com/pkg","Test":"TestSlow","Elapsed":1.25}`,
+			`{"Action":"pass","Package":"example.com/pkg","Elapsed":1.5}`,
+		}, "\n")))
+
+		t.Run("records elapsed seconds of each test", func(t *testing.T) {
+			if elapsed := parser.GetElapsed("example.com/pkg", "TestSlow"); elapsed != 1.25 {
+				t.Errorf("Expected 1.25s, got %v", elapsed)
+			}
+		})
+
+		t.Run("records elapsed seconds of each package", func(t *testing.T) {
+			if elapsed := parser.GetPackageElapsed("example.com/pkg"); elapsed != 1.5 {
+				t.Errorf("Expected 1.5s, got %v", elapsed)
+			}
+		})
+
+		t.Run("falls back to the longest package for wall time", func(t *testing.T) {
+			if wall := parser.GetWallTime(); wall != 1500*time.Millisecond {
+				t.Errorf("Expected 1.5s, got %v", wall)
+			}
+		})
+
+		t.Run("measures wall time from event timestamps", func(t *testing.T) {
+			timed := NewParser()
+			timed.Parse(strings.NewReader(strings.Join([]string{
+				`{"Time":"2025-01-01T10:00:00Z","Action":"start","Package":"example.com/a"}`,
+				`{"Time":"2025-01-01T10:00:03Z","Action":"pass","Package":"example.com/b","Elapsed":1}`,
+				`{"Time":"2025-01-01T10:00:02Z","Action":"pass","Package":"example.com/a","Elapsed":2}`,
+			}, "\n")))
+
+			if wall := timed.GetWallTime(); wall != 3*time.Second {
+				t.Errorf("Expected 3s, got %v", wall)
+			}
+		})
+
+		t.Run("returns zero for unknown tests", func(t *testing.T) {
+			if elapsed := parser.GetElapsed("example.com/other", "TestSlow"); elapsed != 0 {
+				t.Errorf("Expected 0s, got %v", elapsed)
+			}
+		})
+	})
+
+	t.Run("Events without package", func(t *testing.T) {
+		input := strings.Join([]string{
+			`{"Action":"run","Test":"TestAdd"}`,
+			`{"Action":"pass","Test":"TestAdd","Elapsed":0.01}`,
+			`{"Action":"pass","Elapsed":0.02}`,
+		}, "\n")
+
+		t.Run("are attributed to the default package", func(t *testing.T) {
+			results := parseJSON(t, input)
+			if results[DefaultPackage]["TestAdd"] != StatePassed {
+				t.Errorf("Expected TestAdd in %s, got %v", DefaultPackage, results)
+			}
+		})
+
+		t.Run("are attributed to the configured package", func(t *testing.T) {
+			parser := NewParser()
+			parser.SetPackage("example.com/calc")
+			parser.Parse(strings.NewReader(input))
+
+			if parser.GetResults()["example.com/calc"]["TestAdd"] != StatePassed || !parser.HasUnnamedEvents() {
+				t.Errorf("Expected TestAdd in example.com/calc, got %v", parser.GetResults())
+			}
+		})
+
+		t.Run("leave build events alone", func(t *testing.T) {
+			parser := NewParser()
+			parser.Parse(strings.NewReader(`{"ImportPath":"example.com/broken","Action":"build-fail"}`))
+
+			if len(parser.GetResults()) != 0 || parser.HasUnnamedEvents() {
+				t.Errorf("Expected no results, got %v", parser.GetResults())
+			}
+		})
+	})
+
+	t.Run("Cached results", func(t *testing.T) {
+		parser := NewParser()
+		parser.Parse(strings.NewReader(strings.Join([]string{
+			`{"Action":"output","Package":"example.com/cached","Output":"ok  \texample.com/cached\t(cached)\n"}`,
+			`{"Action":"pass","Package":"example.com/cached","Elapsed":0}`,
+			`{"Action":"output","Package":"example.com/fresh","Output":"ok  \texample.com/fresh\t0.012s\n"}`,
+			`{"Action":"pass","Package":"example.com/fresh","Elapsed":0.012}`,
+		}, "\n")))
+
+		t.Run("detects packages served from the cache", func(t *testing.T) {
+			if !parser.IsCached("example.com/cached") {
+				t.Error("Expected cached package")
+			}
+		})
+
+		t.Run("does not mark packages that ran", func(t *testing.T) {
+			if parser.IsCached("example.com/fresh") {
+				t.Error("Expected package that ran not to be cached")
+			}
+		})
+	})
+
+	t.Run("Parallel tests", func(t *testing.T) {
+		parser := NewParser()
+		parser.Parse(strings.NewReader(strings.Join([]string{
+			`{"Time":"2025-01-01T10:00:00Z","Action":"run","Package":"example.com/pkg","Test":"TestA"}`,
+			`{"Time":"2025-01-01T10:00:00Z","Action":"output","Package":"example.com/pkg","Test":"TestA","Output":"=== RUN   TestA\n"}`,
+			`{"Time":"2025-01-01T10:00:00Z","Action":"output","Package":"example.com/pkg","Test":"TestA","Output":"=== PAUSE TestA\n"}`,
+			`{"Time":"2025-01-01T10:00:00Z","Action":"pause","Package":"example.com/pkg","Test":"TestA"}`,
+			`{"Time":"2025-01-01T10:00:00Z","Action":"run","Package":"example.com/pkg","Test":"TestB"}`,
+			`{"Time":"2025-01-01T10:00:00Z","Action":"output","Package":"example.com/pkg","Test":"TestB","Output":"=== RUN   TestB\n"}`,
+			`{"Time":"2025-01-01T10:00:01Z","Action":"output","Package":"example.com/pkg","Test":"TestB","Output":"--- PASS: TestB (1.00s)\n"}`,
+			`{"Time":"2025-01-01T10:00:01Z","Action":"pass","Package":"example.com/pkg","Test":"TestB","Elapsed":1}`,
+			`{"Time":"2025-01-01T10:00:01Z","Action":"cont","Package":"example.com/pkg","Test":"TestA"}`,
+			`{"Time":"2025-01-01T10:00:01Z","Action":"output","Package":"example.com/pkg","Test":"TestA","Output":"=== CONT  TestA\n"}`,
+			`{"Time":"2025-01-01T10:00:02Z","Action":"output","Package":"example.com/pkg","Output":"    a_test.go:9: interleaved\n"}`,
+			`{"Time":"2025-01-01T10:00:03Z","Action":"output","Package":"example.com/pkg","Test":"TestA","Output":"--- FAIL: TestA (2.00s)\n"}`,
+			`{"Time":"2025-01-01T10:00:03Z","Action":"fail","Package":"example.com/pkg","Test":"TestA","Elapsed":2}`,
+			`{"Time":"2025-01-01T10:00:03Z","Action":"output","Package":"example.com/pkg","Output":"FAIL\n"}`,
+			`{"Time":"2025-01-01T10:00:03Z","Action":"fail","Package":"example.com/pkg","Elapsed":3}`,
+		}, "\n")))
+
+		t.Run("measures active and wall time of paused tests", func(t *testing.T) {
+			timing, ok := parser.GetTiming("example.com/pkg", "TestA")
+			expected := Timing{Wall: 3 * time.Second, Active: 2 * time.Second, Parallel: true}
+			if !ok || timing != expected {
+				t.Errorf("Expected %+v, got %+v", expected, timing)
+			}
+		})
+
+		t.Run("measures tests that never paused", func(t *testing.T) {
+			timing, _ := parser.GetTiming("example.com/pkg", "TestB")
+			expected := Timing{Wall: time.Second, Active: time.Second}
+			if timing != expected {
+				t.Errorf("Expected %+v, got %+v", expected, timing)
+			}
+		})
+
+		t.Run("reports no timing without timestamps", func(t *testing.T) {
+			untimed := NewParser()
+			untimed.Parse(strings.NewReader(`{"Action":"run","Package":"example.com/pkg","Test":"TestA"}`))
+
+			if _, ok := untimed.GetTiming("example.com/pkg", "TestA"); ok {
+				t.Error("Expected no timing")
+			}
+		})
+
+		t.Run("excludes lifecycle markers from output", func(t *testing.T) {
+			if output := parser.GetTestOutput("example.com/pkg", "TestA"); strings.Contains(output, "===") {
+				t.Errorf("Expected no markers, got %q", output)
+			}
+		})
+
+		t.Run("attributes interleaved output to the continued test", func(t *testing.T) {
+			if output := parser.GetTestOutput("example.com/pkg", "TestA"); output != "a_test.go:9: interleaved" {
+				t.Errorf("Expected interleaved log line, got %q", output)
+			}
+			if output := parser.GetErrorOutput("example.com/pkg"); output != "FAIL\n" {
+				t.Errorf("Expected only package output, got %q", output)
+			}
+		})
+	})
+
 	t.Run("Build events", func(t *testing.T) {
 		t.Run("TestEvent includes ImportPath field", func(t *testing.T) {
 			input := `{"Action":"build-output","ImportPath":"example.com/pkg","Output":"# example.com/pkg\n"}`
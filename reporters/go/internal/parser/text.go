@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// textMarker matches the lines go test -v prints as a test runs, pauses
+	// or continues, and before output of a test named again
+	textMarker = regexp.MustCompile(`^=== (RUN|PAUSE|CONT|NAME)\s+(\S+)`)
+	// textReport matches the line printed when a test ends, indented by four
+	// spaces per subtest level
+	textReport = regexp.MustCompile(`^(\s*)--- (PASS|FAIL|SKIP): (\S+) \((\d+(?:\.\d+)?)s\)`)
+	// textPackage matches the line printed when a package ends
+	textPackage = regexp.MustCompile(`^(ok  |FAIL|\?   )\t(\S+)(.*)$`)
+	// textElapsed matches the seconds a package took on its line
+	textElapsed = regexp.MustCompile(`\t(\d+(?:\.\d+)?)s`)
+)
+
+// markerActions maps the markers of go test -v to test2json actions
+var markerActions = map[string]string{"RUN": "run", "PAUSE": "pause", "CONT": "cont"}
+
+// reportActions maps test result lines to test2json actions
+var reportActions = map[string]string{"PASS": "pass", "FAIL": "fail", "SKIP": "skip"}
+
+// packageActions maps package result lines to test2json actions
+var packageActions = map[string]string{"ok  ": "pass", "FAIL": "fail", "?   ": "skip"}
+
+// ParseText reads the plain text output of go test without -json. Lines are
+// converted to the events test2json would emit, so the results match those
+// of Parse. Without -v go test only reports failed and skipped tests.
+// Tests of output ending without a package line, like that of a test
+// binary, belong to the package set with SetPackage.
+func (p *Parser) ParseText(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	converter := &textConverter{}
+
+	process := func(events []TestEvent) {
+		for _, event := range events {
+			p.processEvent(&event)
+		}
+	}
+	for scanner.Scan() {
+		process(converter.convert(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	process(converter.flush())
+	return nil
+}
+
+// textConverter turns lines of plain go test output into events. Go test
+// only names the package once it ends, so events are held until then.
+type textConverter struct {
+	pending  []TestEvent
+	test     string   // Test the following output belongs to while it runs
+	reported []string // Tests of the last result lines, by subtest level
+	running  []string // Tests started but not yet ended
+	result   string   // Action of the last PASS or FAIL line
+}
+
+// convert returns the events of a line, with their package once it is known
+func (c *textConverter) convert(line string) []TestEvent {
+	output := line + "\n"
+
+	if m := textPackage.FindStringSubmatch(line); m != nil {
+		pkg, rest := m[2], m[3]
+		end := TestEvent{Action: packageActions[m[1]], Elapsed: packageElapsed(rest)}
+		if strings.Contains(rest, "[build failed]") {
+			end.FailedBuild = pkg
+		}
+		c.pending = append(c.pending, TestEvent{Action: "output", Output: output}, end)
+
+		events := c.pending
+		for i := range events {
+			events[i].Package = pkg
+		}
+		c.pending, c.test, c.reported, c.running, c.result = nil, "", nil, nil, ""
+		return events
+	}
+
+	if m := textMarker.FindStringSubmatch(line); m != nil {
+		c.test, c.reported = m[2], nil
+		if m[1] == "RUN" {
+			c.running = append(c.running, m[2])
+		}
+		if action, ok := markerActions[m[1]]; ok {
+			c.pending = append(c.pending, TestEvent{Action: action, Test: m[2]})
+		}
+		if m[1] == "PAUSE" {
+			c.test = ""
+		}
+		c.pending = append(c.pending, TestEvent{Action: "output", Test: m[2], Output: output})
+		return nil
+	}
+
+	if m := textReport.FindStringSubmatch(line); m != nil {
+		elapsed, _ := strconv.ParseFloat(m[4], 64)
+		level := len(m[1]) / 4
+		c.test, c.reported = "", append(c.reported[:min(level, len(c.reported))], m[3])
+		c.running = slices.DeleteFunc(c.running, func(test string) bool { return test == m[3] })
+		c.pending = append(c.pending,
+			TestEvent{Action: "output", Test: m[3], Output: output},
+			TestEvent{Action: reportActions[m[2]], Test: m[3], Elapsed: elapsed},
+		)
+		return nil
+	}
+
+	// Without -v go test prints logs after the result line of their test,
+	// indented one level deeper than it
+	test := c.test
+	if c.reported != nil {
+		test = ""
+		if level := indentation(line)/4 - 1; level >= 0 && level < len(c.reported) {
+			test = c.reported[level]
+		}
+	}
+	if line == "PASS" || line == "FAIL" {
+		test, c.result = "", reportActions[line]
+	}
+	c.pending = append(c.pending, TestEvent{Action: "output", Test: test, Output: output})
+	return nil
+}
+
+// flush returns the events held at the end of output without a package
+// line, leaving their package to the parser. Tests that never ended fail,
+// as does the package unless its PASS line was printed. Output without
+// any test results is dropped, like the FAIL line go test prints after
+// the packages when one of them failed.
+func (c *textConverter) flush() []TestEvent {
+	started := false
+	for _, event := range c.pending {
+		started = started || event.Test != "" && event.Action != "output"
+	}
+	if !started {
+		return nil
+	}
+
+	events := c.pending
+	for _, test := range c.running {
+		events = append(events, TestEvent{Action: "fail", Test: test})
+	}
+	action := c.result
+	if action == "" || len(c.running) > 0 {
+		action = "fail"
+	}
+	events = append(events, TestEvent{Action: action})
+	c.pending, c.running = nil, nil
+	return events
+}
+
+// packageElapsed returns the seconds on a package line, 0 when cached
+func packageElapsed(rest string) float64 {
+	m := textElapsed.FindStringSubmatch(rest)
+	if m == nil {
+		return 0
+	}
+	elapsed, _ := strconv.ParseFloat(m[1], 64)
+	return elapsed
+}
+
+// indentation returns the number of leading spaces of a line
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
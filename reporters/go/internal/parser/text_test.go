@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseText(t *testing.T) {
+	t.Run("matches results of the JSON output", func(t *testing.T) {
+		text := strings.Join([]string{
+			"=== RUN   TestAdd",
+			"--- PASS: TestAdd (0.00s)",
+			"=== RUN   TestDivide",
+			"=== RUN   TestDivide/by_zero",
+			"    calc_test.go:12: division by zero",
+			"=== RUN   TestDivide/by_one",
+			"--- FAIL: TestDivide (0.02s)",
+			"    --- FAIL: TestDivide/by_zero (0.01s)",
+			"    --- PASS: TestDivide/by_one (0.00s)",
+			"=== RUN   TestNetwork",
+			"    calc_test.go:20: needs network",
+			"--- SKIP: TestNetwork (0.00s)",
+			"FAIL",
+			"FAIL\texample.com/calc\t0.031s",
+			"=== RUN   TestGreet",
+			"--- PASS: TestGreet (0.00s)",
+			"PASS",
+			"ok  \texample.com/greet\t0.012s",
+		}, "\n")
+		json := strings.Join([]string{
+			`{"Action":"run","Package":"example.com/calc","Test":"TestAdd"}`,
+			`{"Action":"pass","Package":"example.com/calc","Test":"TestAdd"}`,
+			`{"Action":"run","Package":"example.com/calc","Test":"TestDivide"}`,
+			`{"Action":"run","Package":"example.com/calc","Test":"TestDivide/by_zero"}`,
+			`{"Action":"fail","Package":"example.com/calc","Test":"TestDivide/by_zero"}`,
+			`{"Action":"pass","Package":"example.com/calc","Test":"TestDivide/by_one"}`,
+			`{"Action":"fail","Package":"example.com/calc","Test":"TestDivide"}`,
+			`{"Action":"skip","Package":"example.com/calc","Test":"TestNetwork"}`,
+			`{"Action":"fail","Package":"example.com/calc"}`,
+			`{"Action":"pass","Package":"example.com/greet","Test":"TestGreet"}`,
+			`{"Action":"pass","Package":"example.com/greet"}`,
+		}, "\n")
+
+		parser := parseText(t, text)
+
+		if expected := parseJSON(t, json); !reflect.DeepEqual(parser.GetResults(), expected) {
+			t.Errorf("Expected %v, got %v", expected, parser.GetResults())
+		}
+		if output := parser.GetTestOutput("example.com/calc", "TestDivide/by_zero"); output != "calc_test.go:12: division by zero" {
+			t.Errorf("Expected log of the running test, got %q", output)
+		}
+		if elapsed := parser.GetElapsed("example.com/calc", "TestDivide"); elapsed != 0.02 {
+			t.Errorf("Expected 0.02s, got %v", elapsed)
+		}
+		if elapsed := parser.GetPackageElapsed("example.com/greet"); elapsed != 0.012 {
+			t.Errorf("Expected 0.012s, got %v", elapsed)
+		}
+	})
+
+	t.Run("attributes logs printed after results without -v", func(t *testing.T) {
+		parser := parseText(t, strings.Join([]string{
+			"--- FAIL: TestDivide (0.00s)",
+			"    --- FAIL: TestDivide/by_zero (0.00s)",
+			"        calc_test.go:12: division by zero",
+			"    calc_test.go:30: cleanup failed",
+			"FAIL",
+			"FAIL\texample.com/calc\t0.010s",
+		}, "\n"))
+
+		if output := parser.GetTestOutput("example.com/calc", "TestDivide/by_zero"); output != "calc_test.go:12: division by zero" {
+			t.Errorf("Expected subtest log, got %q", output)
+		}
+		if output := parser.GetTestOutput("example.com/calc", "TestDivide"); output != "calc_test.go:30: cleanup failed" {
+			t.Errorf("Expected parent log, got %q", output)
+		}
+		if output := parser.GetErrorOutput("example.com/calc"); !strings.HasPrefix(output, "FAIL\n") {
+			t.Errorf("Expected package output, got %q", output)
+		}
+	})
+
+	t.Run("tracks parallel tests", func(t *testing.T) {
+		parser := parseText(t, strings.Join([]string{
+			"=== RUN   TestA",
+			"=== PAUSE TestA",
+			"=== RUN   TestB",
+			"--- PASS: TestB (0.00s)",
+			"=== CONT  TestA",
+			"    a_test.go:9: failed",
+			"--- FAIL: TestA (0.00s)",
+			"FAIL",
+			"FAIL\texample.com/pkg\t0.010s",
+		}, "\n"))
+
+		if output := parser.GetTestOutput("example.com/pkg", "TestA"); output != "a_test.go:9: failed" {
+			t.Errorf("Expected log of the continued test, got %q", output)
+		}
+	})
+
+	t.Run("reads cached packages and packages without tests", func(t *testing.T) {
+		parser := parseText(t, strings.Join([]string{
+			"ok  \texample.com/calc\t(cached)",
+			"?   \texample.com/empty\t[no test files]",
+		}, "\n"))
+
+		results := parser.GetResults()
+		if _, ok := results["example.com/calc"]; !ok {
+			t.Errorf("Expected cached package, got %v", results)
+		}
+		if elapsed := parser.GetPackageElapsed("example.com/calc"); elapsed != 0 {
+			t.Errorf("Expected no elapsed time for cached package, got %v", elapsed)
+		}
+	})
+
+	t.Run("reports build failures", func(t *testing.T) {
+		parser := parseText(t, strings.Join([]string{
+			"# example.com/broken",
+			"./broken.go:3:1: syntax error",
+			"FAIL\texample.com/broken [build failed]",
+		}, "\n"))
+
+		if state := parser.GetResults()["example.com/broken"]["CompilationError"]; state != StateFailed {
+			t.Errorf("Expected failed CompilationError, got %q", state)
+		}
+	})
+
+	t.Run("reports tests without a package line under the set package", func(t *testing.T) {
+		parser := NewParser()
+		parser.SetPackage("example.com/calc")
+
+		err := parser.ParseText(strings.NewReader("=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\nPASS"))
+
+		if err != nil {
+			t.Fatalf("ParseText failed: %v", err)
+		}
+		expected := Results{"example.com/calc": {"TestAdd": StatePassed}}
+		if results := parser.GetResults(); !reflect.DeepEqual(results, expected) {
+			t.Errorf("Expected %v, got %v", expected, results)
+		}
+	})
+
+	t.Run("ignores the FAIL line after the last package", func(t *testing.T) {
+		parser := parseText(t, strings.Join([]string{
+			"--- FAIL: TestDivide (0.00s)",
+			"FAIL",
+			"FAIL\texample.com/calc\t0.01s",
+			"FAIL",
+		}, "\n"))
+
+		expected := Results{"example.com/calc": {"TestDivide": StateFailed}}
+		if results := parser.GetResults(); !reflect.DeepEqual(results, expected) {
+			t.Errorf("Expected %v, got %v", expected, results)
+		}
+		if parser.HasUnnamedEvents() {
+			t.Error("Expected no events without a package")
+		}
+	})
+
+	t.Run("fails tests cut off without a package line", func(t *testing.T) {
+		parser := parseText(t, "=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\n=== RUN   TestDivide\npanic: runtime error")
+
+		expected := Results{DefaultPackage: {"TestAdd": StatePassed, "TestDivide": StateFailed}}
+		if results := parser.GetResults(); !reflect.DeepEqual(results, expected) {
+			t.Errorf("Expected %v, got %v", expected, results)
+		}
+	})
+
+	t.Run("drops output without results", func(t *testing.T) {
+		parser := parseText(t, "some random error text")
+
+		if results := parser.GetResults(); len(results) != 0 {
+			t.Errorf("Expected no results, got %v", results)
+		}
+	})
+}
+
+// Helper functions
+
+func parseText(t *testing.T, input string) *Parser {
+	t.Helper()
+	parser := NewParser()
+	if err := parser.ParseText(strings.NewReader(input)); err != nil {
+		t.Fatalf("ParseText failed: %v", err)
+	}
+	return parser
+}
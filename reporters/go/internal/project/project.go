@@ -0,0 +1,151 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectDirEnv is set by Claude Code to the root of the project
+const ProjectDirEnv = "CLAUDE_PROJECT_DIR"
+
+// rootMarkers identify a project root, in order of preference.
+// Each marker is searched for across all ancestors before trying the next,
+// so a .claude directory at the repository root wins over a nested go.mod.
+var rootMarkers = []struct {
+	name  string
+	isDir bool
+}{
+	{".claude", true},
+	{"go.work", false},
+	{"go.mod", false},
+}
+
+// ResolveRoot determines the project root results are saved under.
+// Precedence: the explicit -project-root value, then CLAUDE_PROJECT_DIR,
+// then the nearest ancestor of the current directory containing a root marker.
+// Returns an empty string when no root can be determined, meaning the current directory.
+func ResolveRoot(explicit string) (string, error) {
+	if explicit != "" {
+		return explicit, ValidateRoot(explicit)
+	}
+
+	if envDir := os.Getenv(ProjectDirEnv); envDir != "" {
+		return envDir, validateProjectDirEnv(envDir)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return FindRoot(cwd), nil
+}
+
+// ValidateRoot checks that an explicit project root is usable
+func ValidateRoot(projectRoot string) error {
+	if !filepath.IsAbs(projectRoot) {
+		return fmt.Errorf("project root must be an absolute path, got %q", projectRoot)
+	}
+
+	return requireWithin("current directory must be within project root", projectRoot)
+}
+
+// validateProjectDirEnv applies the same checks as the TDD Guard CLI
+func validateProjectDirEnv(projectDir string) error {
+	if !filepath.IsAbs(projectDir) {
+		return fmt.Errorf("%s must be an absolute path, got %q", ProjectDirEnv, projectDir)
+	}
+
+	if hasTraversal(projectDir) {
+		return fmt.Errorf("%s must not contain path traversal, got %q", ProjectDirEnv, projectDir)
+	}
+
+	return requireWithin(ProjectDirEnv+" must contain the current working directory", projectDir)
+}
+
+// requireWithin returns an error with the given message unless the current
+// directory is inside root
+func requireWithin(message, root string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("%s: cannot determine current directory: %w", message, err)
+	}
+
+	if !IsWithin(root, cwd) {
+		return fmt.Errorf("%s: %s is not inside %s", message, cwd, root)
+	}
+	return nil
+}
+
+// IsWithin reports whether path is root itself or one of its descendants.
+// Both paths are cleaned and have symlinks resolved, then compared by path
+// component, so /home/me/app does not contain /home/me/app-old.
+func IsWithin(root, path string) bool {
+	rel, err := filepath.Rel(resolvePath(root), resolvePath(path))
+	if err != nil {
+		return false
+	}
+	return rel == "." || !hasParentPrefix(rel)
+}
+
+// resolvePath cleans the path and resolves symlinks.
+// For paths that don't exist yet, the nearest existing ancestor is resolved.
+func resolvePath(path string) string {
+	path = filepath.Clean(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path
+	}
+	return filepath.Join(resolvePath(parent), filepath.Base(path))
+}
+
+// hasParentPrefix checks if a relative path starts by leaving its base directory
+func hasParentPrefix(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// hasTraversal checks if any component of the path is ".."
+func hasTraversal(path string) bool {
+	for _, part := range strings.FieldsFunc(path, isSeparator) {
+		if part == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+func isSeparator(r rune) bool {
+	return r == '/' || r == filepath.Separator
+}
+
+// FindRoot walks up from start looking for a root marker.
+// The .claude directory in the user's home holds global settings rather than
+// project data, so it is not treated as a marker.
+// Returns an empty string when no marker is found.
+func FindRoot(start string) string {
+	home, _ := os.UserHomeDir()
+
+	for _, marker := range rootMarkers {
+		for dir := start; ; dir = filepath.Dir(dir) {
+			if !(marker.isDir && dir == home) && hasMarker(dir, marker.name, marker.isDir) {
+				return dir
+			}
+			if parent := filepath.Dir(dir); parent == dir {
+				break
+			}
+		}
+	}
+
+	return ""
+}
+
+// hasMarker checks if dir contains the named file or directory
+func hasMarker(dir, name string, isDir bool) bool {
+	info, err := os.Stat(filepath.Join(dir, name))
+	return err == nil && info.IsDir() == isDir
+}
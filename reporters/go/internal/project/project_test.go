@@ -0,0 +1,312 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestResolveRoot(t *testing.T) {
+	t.Run("explicit root", func(t *testing.T) {
+		t.Run("takes precedence over environment", func(t *testing.T) {
+			root := chdirTemp(t)
+			t.Setenv(ProjectDirEnv, "/elsewhere")
+
+			resolved, err := ResolveRoot(root)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if resolved != root {
+				t.Errorf("Expected %q, got %q", root, resolved)
+			}
+		})
+
+		t.Run("rejects relative path", func(t *testing.T) {
+			chdirTemp(t)
+
+			_, err := ResolveRoot("relative/path")
+			assertError(t, err, "project root must be an absolute path")
+		})
+	})
+
+	t.Run("CLAUDE_PROJECT_DIR", func(t *testing.T) {
+		t.Run("is used without explicit root", func(t *testing.T) {
+			root := chdirTemp(t)
+			t.Setenv(ProjectDirEnv, root)
+
+			resolved, err := ResolveRoot("")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if resolved != root {
+				t.Errorf("Expected %q, got %q", root, resolved)
+			}
+		})
+
+		t.Run("takes precedence over discovery", func(t *testing.T) {
+			root := chdirTemp(t)
+			nested := mkdir(t, root, "nested")
+			touch(t, nested, "go.mod")
+			os.Chdir(nested)
+			t.Setenv(ProjectDirEnv, root)
+
+			resolved, _ := ResolveRoot("")
+			if resolved != root {
+				t.Errorf("Expected %q, got %q", root, resolved)
+			}
+		})
+
+		t.Run("rejects relative path", func(t *testing.T) {
+			chdirTemp(t)
+			t.Setenv(ProjectDirEnv, "relative/path")
+
+			_, err := ResolveRoot("")
+			assertError(t, err, "CLAUDE_PROJECT_DIR must be an absolute path")
+		})
+
+		t.Run("accepts directory names containing dots", func(t *testing.T) {
+			root := chdirTemp(t)
+			dotted := mkdir(t, root, "my..project")
+			t.Chdir(dotted)
+			t.Setenv(ProjectDirEnv, dotted)
+
+			if _, err := ResolveRoot(""); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		})
+
+		t.Run("rejects path traversal", func(t *testing.T) {
+			root := chdirTemp(t)
+			t.Setenv(ProjectDirEnv, filepath.Join(root, "sub")+"/../")
+
+			_, err := ResolveRoot("")
+			assertError(t, err, "CLAUDE_PROJECT_DIR must not contain path traversal")
+		})
+
+		t.Run("rejects directory not containing current directory", func(t *testing.T) {
+			chdirTemp(t)
+			t.Setenv(ProjectDirEnv, t.TempDir())
+
+			_, err := ResolveRoot("")
+			assertError(t, err, "CLAUDE_PROJECT_DIR must contain the current working directory")
+		})
+	})
+
+	t.Run("discovery", func(t *testing.T) {
+		t.Run("finds root from nested directory", func(t *testing.T) {
+			root := chdirTemp(t)
+			t.Setenv(ProjectDirEnv, "")
+			touch(t, root, "go.mod")
+			os.Chdir(mkdir(t, root, "internal", "pkg"))
+
+			resolved, _ := ResolveRoot("")
+			if resolved != root {
+				t.Errorf("Expected %q, got %q", root, resolved)
+			}
+		})
+
+		t.Run("returns empty string without markers", func(t *testing.T) {
+			chdirTemp(t)
+			t.Setenv(ProjectDirEnv, "")
+
+			if resolved, _ := ResolveRoot(""); resolved != "" {
+				t.Errorf("Expected empty root, got %q", resolved)
+			}
+		})
+	})
+}
+
+func TestValidateRoot(t *testing.T) {
+	t.Run("accepts symlinked project root", func(t *testing.T) {
+		root := chdirTemp(t)
+		link := symlink(t, root)
+
+		if err := ValidateRoot(link); err != nil {
+			t.Fatalf("Expected symlinked root to be accepted, got: %v", err)
+		}
+	})
+
+	t.Run("accepts project root when current directory is reached through a symlink", func(t *testing.T) {
+		root := realTempDir(t)
+		t.Chdir(symlink(t, root))
+
+		if err := ValidateRoot(root); err != nil {
+			t.Fatalf("Expected root to be accepted, got: %v", err)
+		}
+	})
+
+	t.Run("rejects sibling sharing a name prefix", func(t *testing.T) {
+		parent := realTempDir(t)
+		t.Chdir(mkdir(t, parent, "app-old"))
+
+		err := ValidateRoot(mkdir(t, parent, "app"))
+		assertError(t, err, "current directory must be within project root")
+	})
+
+	t.Run("names both paths in the error", func(t *testing.T) {
+		parent := realTempDir(t)
+		cwd := mkdir(t, parent, "app-old")
+		root := mkdir(t, parent, "app")
+		t.Chdir(cwd)
+
+		err := ValidateRoot(root)
+		if err == nil || !strings.Contains(err.Error(), cwd) || !strings.Contains(err.Error(), root) {
+			t.Fatalf("Expected error to name %s and %s, got: %v", cwd, root, err)
+		}
+	})
+}
+
+func TestIsWithin(t *testing.T) {
+	sep := string(filepath.Separator)
+	root := filepath.Join(sep, "home", "me", "app")
+
+	testCases := []struct {
+		name     string
+		path     string
+		expected bool
+	}{
+		{"same directory", root, true},
+		{"same directory with trailing separator", root + sep, true},
+		{"child directory", filepath.Join(root, "pkg"), true},
+		{"nested child directory", filepath.Join(root, "internal", "pkg"), true},
+		{"child with dots in its name", filepath.Join(root, "..pkg"), true},
+		{"unclean path inside root", root + sep + "pkg" + sep + ".." + sep + "cmd", true},
+		{"sibling sharing a name prefix", root + "-old", false},
+		{"parent directory", filepath.Dir(root), false},
+		{"unclean path leaving root", root + sep + ".." + sep + "other", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsWithin(root, tc.path); got != tc.expected {
+				t.Errorf("IsWithin(%q, %q) = %v, expected %v", root, tc.path, got, tc.expected)
+			}
+		})
+	}
+
+	t.Run("resolves symlinks", func(t *testing.T) {
+		real := realTempDir(t)
+		link := symlink(t, real)
+
+		if !IsWithin(link, filepath.Join(real, "pkg")) {
+			t.Error("Expected path in real directory to be within symlinked root")
+		}
+		if !IsWithin(real, filepath.Join(link, "pkg")) {
+			t.Error("Expected path through symlink to be within real root")
+		}
+	})
+}
+
+func TestFindRoot(t *testing.T) {
+	t.Run("prefers .claude directory over nested go.mod", func(t *testing.T) {
+		root := realTempDir(t)
+		mkdir(t, root, ".claude")
+		module := mkdir(t, root, "service")
+		touch(t, module, "go.mod")
+
+		if found := FindRoot(mkdir(t, module, "pkg")); found != root {
+			t.Errorf("Expected %q, got %q", root, found)
+		}
+	})
+
+	t.Run("prefers go.work over nested go.mod", func(t *testing.T) {
+		root := realTempDir(t)
+		touch(t, root, "go.work")
+		module := mkdir(t, root, "service")
+		touch(t, module, "go.mod")
+
+		if found := FindRoot(mkdir(t, module, "pkg")); found != root {
+			t.Errorf("Expected %q, got %q", root, found)
+		}
+	})
+
+	t.Run("uses nearest go.mod", func(t *testing.T) {
+		root := realTempDir(t)
+		module := mkdir(t, root, "service")
+		touch(t, module, "go.mod")
+
+		if found := FindRoot(mkdir(t, module, "pkg")); found != module {
+			t.Errorf("Expected %q, got %q", module, found)
+		}
+	})
+
+	t.Run("ignores markers of the wrong type", func(t *testing.T) {
+		root := realTempDir(t)
+		touch(t, root, ".claude")
+		touch(t, root, "go.mod")
+		nested := mkdir(t, root, "pkg")
+		mkdir(t, nested, "go.mod")
+
+		if found := FindRoot(nested); found != root {
+			t.Errorf("Expected %q, got %q", root, found)
+		}
+	})
+
+	t.Run("ignores .claude directory in home", func(t *testing.T) {
+		home := realTempDir(t)
+		t.Setenv("HOME", home)
+		t.Setenv("USERPROFILE", home)
+		mkdir(t, home, ".claude")
+		module := mkdir(t, home, "project")
+		touch(t, module, "go.mod")
+
+		if found := FindRoot(mkdir(t, module, "pkg")); found != module {
+			t.Errorf("Expected %q, got %q", module, found)
+		}
+	})
+}
+
+// Helper functions
+
+func realTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := realTempDir(t)
+	t.Chdir(dir)
+	return dir
+}
+
+func symlink(t *testing.T, target string) string {
+	t.Helper()
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(target, link); err != nil {
+		if runtime.GOOS == "windows" {
+			t.Skipf("Symlinks not available: %v", err)
+		}
+		t.Fatal(err)
+	}
+	return link
+}
+
+func mkdir(t *testing.T, parts ...string) string {
+	t.Helper()
+	dir := filepath.Join(parts...)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func touch(t *testing.T, dir, name string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertError(t *testing.T, err error, expected string) {
+	t.Helper()
+	if err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Fatalf("Expected error starting with '%s', got: %v", expected, err)
+	}
+}
@@ -0,0 +1,314 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/cache"
+	"github.com/nizos/tdd-guard/reporters/go/internal/filter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/merger"
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/resolver"
+	"github.com/nizos/tdd-guard/reporters/go/internal/slow"
+	"github.com/nizos/tdd-guard/reporters/go/internal/storage"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// Reporter collects the results of a test run and saves them for TDD Guard.
+// Feed it go test output with Read or events with Add, then call Finish.
+// A Reporter reports a single run and is not safe for concurrent use.
+type Reporter struct {
+	root             string
+	storage          *storage.Storage
+	filter           *filter.Filter
+	locator          *resolver.Resolver
+	parser           *parser.Parser
+	compilationError *parser.CompilationError
+	thresholds       *slow.Thresholds
+	budgets          *slow.Budgets
+	slowStrict       bool
+	merge            bool
+	maxAge           time.Duration
+	maxErrorBytes    int
+	notRun           []string
+	sinks            []Sink
+	filtered         filter.Summary
+}
+
+// Filter selects the packages and tests saved in the results. Patterns are
+// globs matched against the full import path or test name, or regular
+// expressions when they start with "re:". Without include patterns
+// everything not excluded is kept.
+type Filter struct {
+	IncludePackages []string
+	ExcludePackages []string
+	IncludeTests    []string
+	ExcludeTests    []string
+	Mode            filter.Mode
+}
+
+// Sink receives the results of each run once test.json is saved, without
+// the earlier results merged into it. Implementations must not modify the
+// result.
+type Sink interface {
+	Write(result *transformer.TestResult) error
+}
+
+// NewReporter creates a reporter saving results in the data directory of
+// the project at root. Module IDs and test locations are resolved against
+// the project's modules. An empty root saves relative to the current
+// directory and reports import paths as module IDs.
+func NewReporter(root string) *Reporter {
+	r := &Reporter{
+		root:       root,
+		storage:    storage.NewStorage(root),
+		parser:     parser.NewParser(),
+		thresholds: &slow.Thresholds{},
+		budgets:    &slow.Budgets{},
+	}
+	r.filter, _ = filter.NewFilter(nil, nil)
+	if root != "" {
+		r.locator = resolver.NewResolver(root)
+	}
+	return r
+}
+
+// SetDataDir saves results in dir instead of the project's default data
+// directory. Relative directories are resolved from the project root.
+func (r *Reporter) SetDataDir(dir string) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.root, dir)
+	}
+	r.storage = storage.NewStorageInDir(dir)
+}
+
+// SetLockTimeout changes how long saving waits for other writers
+func (r *Reporter) SetLockTimeout(timeout time.Duration) {
+	r.storage.SetLockTimeout(timeout)
+}
+
+// SetFilter selects the packages and tests saved in the results
+func (r *Reporter) SetFilter(f Filter) error {
+	resultFilter, err := filter.NewFilter(f.IncludePackages, f.ExcludePackages)
+	if err != nil {
+		return fmt.Errorf("invalid package pattern: %w", err)
+	}
+	if err := resultFilter.SetTestPatterns(f.IncludeTests, f.ExcludeTests); err != nil {
+		return fmt.Errorf("invalid test pattern: %w", err)
+	}
+	if f.Mode != "" {
+		resultFilter.SetMode(f.Mode)
+	}
+	r.filter = resultFilter
+	return nil
+}
+
+// SetMerge merges results into the previously saved results instead of
+// replacing them, dropping merged results older than maxAge unless it is 0
+func (r *Reporter) SetMerge(enabled bool, maxAge time.Duration) {
+	r.merge = enabled
+	r.maxAge = maxAge
+}
+
+// SetMaxErrorBytes limits the size of each saved error message, 0 keeps them whole
+func (r *Reporter) SetMaxErrorBytes(limit int) {
+	r.maxErrorBytes = limit
+}
+
+// SetSlowThresholds flags tests taking longer than their threshold as slow.
+// perPackage entries of the form "pattern=duration" override the global
+// threshold. In strict mode slow tests also fail.
+func (r *Reporter) SetSlowThresholds(global time.Duration, perPackage []string, strict bool) error {
+	thresholds, err := slow.NewThresholds(global, perPackage)
+	if err != nil {
+		return err
+	}
+	r.thresholds = thresholds
+	r.slowStrict = strict
+	return nil
+}
+
+// SetSlowBudgets limits the total time of packages with entries of the form
+// "pattern=duration". A package over its budget is reported as a warning,
+// or fails in the strict mode of SetSlowThresholds.
+func (r *Reporter) SetSlowBudgets(budgets []string) error {
+	b, err := slow.NewBudgets(budgets)
+	if err != nil {
+		return err
+	}
+	r.budgets = b
+	return nil
+}
+
+// SetPackage names the package of events without one, like those of go tool
+// test2json without -p. A test binary such as ./calc.test names the package
+// in the current directory when go test -c names its binaries so, as when
+// go test runs the binary, or else the only package of the project it names
+// so. Import paths ending in a major version, like example.com/calc/v2,
+// name binaries after the element before it. Binaries of test files named
+// on the command line name parser.DefaultPackage.
+func (r *Reporter) SetPackage(pkg string) error {
+	name := strings.TrimSuffix(filepath.Base(pkg), ".exe")
+	if !strings.HasSuffix(name, ".test") {
+		r.parser.SetPackage(pkg)
+		return nil
+	}
+	name = strings.TrimSuffix(name, ".test")
+	if name == parser.DefaultPackage {
+		r.parser.SetPackage(name)
+		return nil
+	}
+	if r.locator != nil {
+		if dir, err := os.Getwd(); err == nil {
+			if found, ok := r.locator.PackageAt(dir); ok && resolver.BinaryName(found) == name {
+				r.parser.SetPackage(found)
+				return nil
+			}
+		}
+		if found, ok := r.locator.FindPackage(name); ok {
+			r.parser.SetPackage(found)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot find the package of test binary %s", pkg)
+}
+
+// Package returns the package of events without one
+func (r *Reporter) Package() string {
+	return r.parser.Package()
+}
+
+// SetNotRun lists packages the run intentionally left out, such as
+// packages unaffected by a change
+func (r *Reporter) SetNotRun(packages []string) {
+	r.notRun = packages
+}
+
+// AddSink also writes the results of the run to sink
+func (r *Reporter) AddSink(sink Sink) {
+	r.sinks = append(r.sinks, sink)
+}
+
+// Add records a single test event
+func (r *Reporter) Add(event parser.TestEvent) {
+	r.parser.Add(event)
+}
+
+// Read records the output of go test. JSON events are read when there are
+// any; otherwise the plain text output of go test without -json is read.
+// Build errors printed before the events are kept for the results.
+func (r *Reporter) Read(reader io.Reader) error {
+	mixedReader := parser.NewMixedReader(reader)
+	if mixedReader.CompilationError != nil {
+		r.compilationError = mixedReader.CompilationError
+	}
+
+	if len(mixedReader.JSONLines) > 0 {
+		return r.parser.Parse(strings.NewReader(strings.Join(mixedReader.JSONLines, "\n")))
+	}
+	return r.parser.ParseText(strings.NewReader(strings.Join(mixedReader.NonJSONLines, "\n")))
+}
+
+// ReadText records plain text go test output, such as that of a test
+// binary run with -test.v, even when it contains lines that look like JSON
+func (r *Reporter) ReadText(reader io.Reader) error {
+	return r.parser.ParseText(reader)
+}
+
+// Finish builds the results of the run, saves them, merged with earlier
+// results when merging is enabled, and writes them to every sink. The
+// returned result describes this run, with the transitions of the saved
+// results, and is returned along with the joined errors of saving and the
+// sinks.
+func (r *Reporter) Finish() (*transformer.TestResult, error) {
+	result := r.result()
+
+	err := r.storage.Update(func(previous *transformer.TestResult) (*transformer.TestResult, error) {
+		saved := result
+		if r.merge {
+			// Tests kept from earlier runs compare as unchanged, so a partial run
+			// only reports transitions for the tests it actually ran
+			m := merger.NewMerger(r.maxAge)
+			if savedAt, ok := cache.LastModified(r.storage.Path()); ok {
+				m.SetSavedAt(savedAt)
+			}
+			saved = m.Merge(previous, result, transformer.NewRun(time.Now()))
+		}
+		saved.Transitions = transformer.CompareResults(previous, saved)
+		result.Transitions = saved.Transitions
+		return saved, nil
+	})
+	var errs []error
+	if err != nil {
+		errs = append(errs, fmt.Errorf("save test results: %w", err))
+	}
+
+	// Other formats describe this run only, without earlier merged results
+	for _, sink := range r.sinks {
+		errs = append(errs, sink.Write(result))
+	}
+	return result, errors.Join(errs...)
+}
+
+// result transforms the recorded events into the results of the run
+func (r *Reporter) result() *transformer.TestResult {
+	results := r.parser.GetResults()
+
+	// Add synthetic test for compilation errors
+	if len(results) == 0 && r.compilationError != nil {
+		results[r.compilationError.Package] = parser.PackageResults{
+			parser.CompilationErrorTest: parser.StateFailed,
+		}
+	}
+
+	results, r.filtered = r.filter.Apply(results)
+
+	t := transformer.NewTransformer()
+	t.SetMaxErrorBytes(r.maxErrorBytes)
+	if r.locator != nil {
+		t.SetLocator(r.locator)
+	}
+	result := t.Transform(results, r.parser, r.compilationError)
+	for _, pkg := range r.notRun {
+		if r.filter.IncludesPackage(pkg) {
+			result.NotRun = append(result.NotRun, t.ModuleID(pkg))
+		}
+	}
+
+	r.thresholds.Mark(result, r.slowStrict)
+	r.budgets.Mark(result, r.slowStrict)
+
+	if lastRun, ok := cache.LastModified(r.storage.Path()); r.locator != nil && ok {
+		result.Warnings = append(result.Warnings, cache.Warn(result, r.root, r.locator, lastRun)...)
+	}
+	return result
+}
+
+// WallTime returns how long the run took, from the first to the last event
+func (r *Reporter) WallTime() time.Duration {
+	return r.parser.GetWallTime()
+}
+
+// Filtered describes how many packages and tests the filter removed or
+// skipped in Finish, or returns an empty string when it kept everything
+func (r *Reporter) Filtered() string {
+	if r.filtered.IsEmpty() {
+		return ""
+	}
+	return r.filtered.String()
+}
+
+// HasUnnamedEvents reports whether any event lacked a package
+func (r *Reporter) HasUnnamedEvents() bool {
+	return r.parser.HasUnnamedEvents()
+}
+
+// ResultsPath returns the location of the saved results
+func (r *Reporter) ResultsPath() string {
+	return r.storage.Path()
+}
@@ -0,0 +1,297 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestReporter(t *testing.T) {
+	input := strings.Join([]string{
+		`{"Action":"pass","Package":"example.com/calc","Test":"TestAdd","Elapsed":0.01}`,
+		`{"Action":"output","Package":"example.com/calc","Test":"TestDivide","Output":"    calc_test.go:12: division by zero\n"}`,
+		`{"Action":"fail","Package":"example.com/calc","Test":"TestDivide","Elapsed":0.02}`,
+		`{"Action":"fail","Package":"example.com/calc","Elapsed":0.03}`,
+		`{"Action":"pass","Package":"example.com/store","Test":"TestSave"}`,
+		`{"Action":"pass","Package":"example.com/store"}`,
+	}, "\n")
+
+	t.Run("Read", func(t *testing.T) {
+		t.Run("returns and saves the results of go test output", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			if err := reporter.Read(strings.NewReader(input)); err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+
+			result, err := reporter.Finish()
+			if err != nil {
+				t.Fatalf("Finish failed: %v", err)
+			}
+
+			if result.Reason != "failed" || len(result.TestModules) != 2 {
+				t.Errorf("Expected failed result with two modules, got %+v", result)
+			}
+			saved := readSaved(t, reporter)
+			if saved.Reason != "failed" || len(saved.TestModules) != 2 {
+				t.Errorf("Expected saved results, got %+v", saved)
+			}
+		})
+
+		t.Run("reads plain text output", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			reporter.Read(strings.NewReader("--- FAIL: TestDivide (0.00s)\nFAIL\nFAIL\texample.com/calc\t0.010s\n"))
+
+			result, _ := reporter.Finish()
+
+			if result.Reason != "failed" || result.TestModules[0].Tests[0].Name != "TestDivide" {
+				t.Errorf("Expected failed TestDivide, got %+v", result)
+			}
+		})
+
+		t.Run("reads plain text output printing JSON", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			reporter.ReadText(strings.NewReader("=== RUN   TestEncode\n{\"id\":1}\n--- PASS: TestEncode (0.00s)\nPASS\nok  \texample.com/calc\t0.010s\n"))
+
+			result, _ := reporter.Finish()
+
+			if result.Reason != "passed" || result.TestModules[0].Tests[0].Name != "TestEncode" {
+				t.Errorf("Expected passing TestEncode, got %+v", result)
+			}
+		})
+
+		t.Run("reports build errors", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			reporter.Read(strings.NewReader("# example.com/broken\n./broken.go:3:1: syntax error\n"))
+
+			result, _ := reporter.Finish()
+
+			test := result.TestModules[0].Tests[0]
+			if test.Name != "CompilationError" || test.Errors[0].Message != "./broken.go:3:1: syntax error" {
+				t.Errorf("Expected compilation error, got %+v", test)
+			}
+		})
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		t.Run("records events one at a time", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			reporter.Add(parser.TestEvent{Action: "run", Package: "example.com/calc", Test: "TestAdd"})
+			reporter.Add(parser.TestEvent{Action: "pass", Package: "example.com/calc", Test: "TestAdd"})
+
+			result, _ := reporter.Finish()
+
+			if result.Reason != "passed" || result.TestModules[0].Tests[0].FullName != "example.com/calc/TestAdd" {
+				t.Errorf("Expected passing TestAdd, got %+v", result)
+			}
+		})
+
+		t.Run("attributes events without a package", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			reporter.SetPackage("example.com/calc")
+			reporter.Add(parser.TestEvent{Action: "pass", Test: "TestAdd"})
+
+			result, _ := reporter.Finish()
+
+			if !reporter.HasUnnamedEvents() || result.TestModules[0].ModuleID != "example.com/calc" {
+				t.Errorf("Expected TestAdd in example.com/calc, got %+v", result)
+			}
+		})
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		t.Run("filters packages and tests", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			err := reporter.SetFilter(Filter{ExcludePackages: []string{"example.com/store"}, ExcludeTests: []string{"TestDivide"}})
+			if err != nil {
+				t.Fatalf("SetFilter failed: %v", err)
+			}
+			reporter.Read(strings.NewReader(input))
+
+			result, _ := reporter.Finish()
+
+			if len(result.TestModules) != 1 || len(result.TestModules[0].Tests) != 1 {
+				t.Errorf("Expected only TestAdd, got %+v", result)
+			}
+			if filtered := reporter.Filtered(); filtered != "filtered 1 package and 1 test" {
+				t.Errorf("Expected filter summary, got %q", filtered)
+			}
+		})
+
+		t.Run("rejects invalid filter patterns", func(t *testing.T) {
+			if err := NewReporter("").SetFilter(Filter{IncludeTests: []string{"re:("}}); err == nil {
+				t.Error("Expected error for invalid pattern")
+			}
+		})
+
+		t.Run("saves in the data directory", func(t *testing.T) {
+			root := t.TempDir()
+			reporter := NewReporter(root)
+			reporter.SetDataDir("results")
+			reporter.Read(strings.NewReader(input))
+
+			if _, err := reporter.Finish(); err != nil {
+				t.Fatalf("Finish failed: %v", err)
+			}
+
+			if _, err := os.Stat(filepath.Join(root, "results", "test.json")); err != nil {
+				t.Errorf("Expected results in data directory: %v", err)
+			}
+		})
+
+		t.Run("merges with earlier results", func(t *testing.T) {
+			root := t.TempDir()
+			first := NewReporter(root)
+			first.SetMerge(true, 0)
+			first.Read(strings.NewReader(input))
+			first.Finish()
+
+			second := NewReporter(root)
+			second.SetMerge(true, 0)
+			second.Add(parser.TestEvent{Action: "pass", Package: "example.com/calc", Test: "TestDivide"})
+			result, _ := second.Finish()
+
+			if len(result.TestModules) != 1 || len(result.Transitions.NewlyPassed) != 1 {
+				t.Errorf("Expected this run with its transitions, got %+v", result)
+			}
+			if saved := readSaved(t, second); len(saved.TestModules) != 2 {
+				t.Errorf("Expected merged results, got %+v", saved)
+			}
+		})
+
+		t.Run("writes results to sinks", func(t *testing.T) {
+			sink := &recordingSink{}
+			reporter := NewReporter(t.TempDir())
+			reporter.AddSink(sink)
+			reporter.Read(strings.NewReader(input))
+
+			reporter.Finish()
+
+			if len(sink.results) != 1 || sink.results[0].Reason != "failed" {
+				t.Errorf("Expected the run's results, got %+v", sink.results)
+			}
+		})
+
+		t.Run("saves results and writes later sinks when a sink fails", func(t *testing.T) {
+			first, second := errors.New("first sink failed"), errors.New("second sink failed")
+			sink := &recordingSink{}
+			reporter := NewReporter(t.TempDir())
+			reporter.AddSink(&failingSink{err: first})
+			reporter.AddSink(sink)
+			reporter.AddSink(&failingSink{err: second})
+			reporter.Read(strings.NewReader(input))
+
+			result, err := reporter.Finish()
+
+			if !errors.Is(err, first) || !errors.Is(err, second) {
+				t.Errorf("Expected both sink errors, got %v", err)
+			}
+			if result == nil || len(sink.results) != 1 {
+				t.Errorf("Expected the run's results despite the errors, got %+v and %+v", result, sink.results)
+			}
+			if saved := readSaved(t, reporter); saved.Reason != "failed" {
+				t.Errorf("Expected saved results, got %+v", saved)
+			}
+		})
+
+		t.Run("flags slow tests", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			if err := reporter.SetSlowThresholds(0, []string{"example.com/calc=15ms"}, false); err != nil {
+				t.Fatalf("SetSlowThresholds failed: %v", err)
+			}
+			reporter.Read(strings.NewReader(input))
+
+			result, _ := reporter.Finish()
+
+			for _, test := range result.TestModules[0].Tests {
+				if test.Slow != (test.Name == "TestDivide") {
+					t.Errorf("Expected only TestDivide flagged, got %s slow=%v", test.Name, test.Slow)
+				}
+			}
+		})
+
+		t.Run("warns about packages over their budget", func(t *testing.T) {
+			reporter := NewReporter(t.TempDir())
+			if err := reporter.SetSlowBudgets([]string{"example.com/calc=25ms"}); err != nil {
+				t.Fatalf("SetSlowBudgets failed: %v", err)
+			}
+			reporter.Read(strings.NewReader(input))
+
+			result, _ := reporter.Finish()
+
+			if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "exceeding its slow budget of 25ms") {
+				t.Errorf("Expected budget warning, got %q", result.Warnings)
+			}
+		})
+
+		t.Run("finds the package of a test binary in the current directory", func(t *testing.T) {
+			root := t.TempDir()
+			os.MkdirAll(filepath.Join(root, "api", "store"), 0755)
+			os.MkdirAll(filepath.Join(root, "cmd", "store"), 0755)
+			os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644)
+			os.WriteFile(filepath.Join(root, "api", "store", "store_test.go"), []byte("package store\n"), 0644)
+			os.WriteFile(filepath.Join(root, "cmd", "store", "store_test.go"), []byte("package main\n"), 0644)
+			t.Chdir(filepath.Join(root, "api", "store"))
+			reporter := NewReporter(root)
+
+			if err := reporter.SetPackage("/tmp/go-build/b001/store.test"); err != nil || reporter.Package() != "example.com/app/api/store" {
+				t.Errorf("Expected example.com/app/api/store, got %q (%v)", reporter.Package(), err)
+			}
+		})
+
+		t.Run("finds the package of a test binary", func(t *testing.T) {
+			root := t.TempDir()
+			os.MkdirAll(filepath.Join(root, "calc"), 0755)
+			os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644)
+			os.WriteFile(filepath.Join(root, "calc", "calc_test.go"), []byte("package calc\n"), 0644)
+			reporter := NewReporter(root)
+
+			if err := reporter.SetPackage("bin/calc.test"); err != nil || reporter.Package() != "example.com/app/calc" {
+				t.Errorf("Expected example.com/app/calc, got %q (%v)", reporter.Package(), err)
+			}
+			if err := reporter.SetPackage("bin/command-line-arguments.test"); err != nil || reporter.Package() != parser.DefaultPackage {
+				t.Errorf("Expected %s, got %q (%v)", parser.DefaultPackage, reporter.Package(), err)
+			}
+			if err := reporter.SetPackage("bin/missing.test"); err == nil {
+				t.Error("Expected error for unknown test binary")
+			}
+		})
+	})
+}
+
+// Helper functions
+
+type failingSink struct {
+	err error
+}
+
+func (s *failingSink) Write(*transformer.TestResult) error {
+	return s.err
+}
+
+type recordingSink struct {
+	results []*transformer.TestResult
+}
+
+func (s *recordingSink) Write(result *transformer.TestResult) error {
+	s.results = append(s.results, result)
+	return nil
+}
+
+func readSaved(t *testing.T, reporter *Reporter) *transformer.TestResult {
+	t.Helper()
+	data, err := os.ReadFile(reporter.ResultsPath())
+	if err != nil {
+		t.Fatalf("Expected saved results: %v", err)
+	}
+	var result *transformer.TestResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Invalid saved results: %v", err)
+	}
+	return result
+}
@@ -0,0 +1,337 @@
+package resolver
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Module is a Go module found in the project
+type Module struct {
+	Path string
+	Dir  string
+}
+
+// location is a position in a source file relative to the project root
+type location struct {
+	file string
+	line int
+}
+
+// Resolver maps package import paths to source directories relative to the
+// project root, without running the go tool. Modules are read from the
+// go.work file at the project root or, without one, from every go.mod file
+// in the project tree.
+type Resolver struct {
+	root    string
+	modules []Module
+	tests   map[string]map[string]location
+}
+
+// NewResolver discovers the modules of the project at root
+func NewResolver(root string) *Resolver {
+	r := &Resolver{
+		root:    root,
+		modules: FindModules(root),
+		tests:   make(map[string]map[string]location),
+	}
+
+	// Longest module path first, so nested modules win over their parents
+	sort.SliceStable(r.modules, func(i, j int) bool {
+		return len(r.modules[i].Path) > len(r.modules[j].Path)
+	})
+	return r
+}
+
+// FindModules lists the modules of the project at root, sorted by directory.
+// Modules are read from the go.work file at the root or, without one, from
+// every go.mod file in the project tree.
+func FindModules(root string) []Module {
+	var modules []Module
+	if dirs, ok := readWorkspace(filepath.Join(root, "go.work")); ok {
+		for _, dir := range dirs {
+			modules = appendModule(modules, dir)
+		}
+	} else {
+		modules = findModules(root)
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Dir < modules[j].Dir
+	})
+	return modules
+}
+
+// PackageDir returns the package's directory relative to the project root,
+// using forward slashes. Returns false when the package is not part of a
+// project module or its directory does not exist.
+func (r *Resolver) PackageDir(pkg string) (string, bool) {
+	dir, ok := r.packagePath(pkg)
+	if !ok {
+		return "", false
+	}
+	return r.relative(dir)
+}
+
+// TestLocation returns the file, relative to the project root, and line
+// declaring the test function in the package's _test.go files. Subtests are
+// located at their top-level test. Returns false when no declaration is found.
+func (r *Resolver) TestLocation(pkg, test string) (string, int, bool) {
+	dir, ok := r.packagePath(pkg)
+	if !ok {
+		return "", 0, false
+	}
+
+	topLevel, _, _ := strings.Cut(test, "/")
+	loc, ok := r.testLocations(dir)[topLevel]
+	return loc.file, loc.line, ok
+}
+
+// majorVersion matches the major version suffix of a module path, like v2
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// BinaryName returns the name go test -c gives the test binary of the
+// package, without its .test suffix: the last element of the import path
+// that is not a major version, so example.com/calc/v2 is named calc
+func BinaryName(importPath string) string {
+	dir, elem := path.Split(importPath)
+	if dir != "" && majorVersion.MatchString(elem) {
+		return path.Base(dir)
+	}
+	return elem
+}
+
+// FindPackage returns the import path of the only package in the project
+// with tests whose BinaryName is name. This finds the package of a test
+// binary even when a module root directory is named differently.
+func (r *Resolver) FindPackage(name string) (string, bool) {
+	found := make(map[string]bool)
+	for _, m := range r.modules {
+		filepath.WalkDir(m.Dir, func(dir string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			if dir != m.Dir && (skipDir(d.Name()) || isFile(filepath.Join(dir, "go.mod"))) {
+				return filepath.SkipDir
+			}
+			rel, _ := filepath.Rel(m.Dir, dir)
+			pkg := strings.TrimSuffix(m.Path+"/"+filepath.ToSlash(rel), "/.")
+			if BinaryName(pkg) != name {
+				return nil
+			}
+			if tests, _ := filepath.Glob(filepath.Join(dir, "*_test.go")); len(tests) > 0 {
+				found[pkg] = true
+			}
+			return nil
+		})
+	}
+	if len(found) != 1 {
+		return "", false
+	}
+	for pkg := range found {
+		return pkg, true
+	}
+	return "", false
+}
+
+// PackageAt returns the import path of the package in the absolute
+// directory dir, from the innermost project module containing it. Returns
+// false when no module contains the directory.
+func (r *Resolver) PackageAt(dir string) (string, bool) {
+	var pkg, moduleDir string
+	for _, m := range r.modules {
+		rel, err := filepath.Rel(m.Dir, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(m.Dir) > len(moduleDir) {
+			pkg = strings.TrimSuffix(m.Path+"/"+filepath.ToSlash(rel), "/.")
+			moduleDir = m.Dir
+		}
+	}
+	return pkg, moduleDir != ""
+}
+
+// packagePath returns the absolute directory of the package
+func (r *Resolver) packagePath(pkg string) (string, bool) {
+	for _, m := range r.modules {
+		var rest string
+		switch {
+		case pkg == m.Path:
+		case strings.HasPrefix(pkg, m.Path+"/"):
+			rest = pkg[len(m.Path)+1:]
+		default:
+			continue
+		}
+
+		dir := filepath.Join(m.Dir, filepath.FromSlash(rest))
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// testLocations maps test function names to their declarations, parsing
+// each package directory once
+func (r *Resolver) testLocations(dir string) map[string]location {
+	if locations, exists := r.tests[dir]; exists {
+		return locations
+	}
+
+	locations := make(map[string]location)
+	r.tests[dir] = locations
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		file, ok := r.relative(path)
+		if !ok {
+			continue
+		}
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				line := fset.Position(fn.Name.Pos()).Line
+				locations[fn.Name.Name] = location{file: file, line: line}
+			}
+		}
+	}
+	return locations
+}
+
+// relative converts an absolute path to a slash-separated path relative to the root
+func (r *Resolver) relative(path string) (string, bool) {
+	rel, err := filepath.Rel(r.root, path)
+	if err != nil {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// appendModule adds the module whose go.mod is in dir
+func appendModule(modules []Module, dir string) []Module {
+	if path, ok := readModulePath(filepath.Join(dir, "go.mod")); ok {
+		modules = append(modules, Module{Path: path, Dir: dir})
+	}
+	return modules
+}
+
+// findModules walks the tree at root for go.mod files, skipping directories
+// the go tool ignores
+func findModules(root string) []Module {
+	var modules []Module
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == "go.mod" {
+			modules = appendModule(modules, filepath.Dir(path))
+		}
+		return nil
+	})
+	return modules
+}
+
+// skipDir checks if the go tool ignores directories with the name
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || name == "node_modules" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// isFile checks if path exists and is a regular file
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// readModulePath reads the module directive of a go.mod file
+func readModulePath(goMod string) (string, bool) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 2 && fields[0] == "module" {
+			return unquote(fields[1]), true
+		}
+	}
+	return "", false
+}
+
+// readWorkspace reads the module directories listed by use directives in a go.work file
+func readWorkspace(goWork string) ([]string, bool) {
+	f, err := os.Open(goWork)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var dirs []string
+	inBlock := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(stripComment(scanner.Text()))
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+		case fields[0] == "use" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "use" && len(fields) == 2:
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		dir := filepath.FromSlash(unquote(fields[0]))
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(goWork), dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, true
+}
+
+// stripComment removes a trailing // comment
+func stripComment(line string) string {
+	if i := strings.Index(line, "//"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// unquote removes the quotes go.mod allows around paths
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
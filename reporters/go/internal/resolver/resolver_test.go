@@ -0,0 +1,287 @@
+package resolver
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	t.Run("Single module", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app // the app\n\ngo 1.24\n")
+		writeFile(t, root, "app_test.go", "package app\n\nfunc TestRoot(t *testing.T) {}\n")
+		writeFile(t, root, "internal/foo/foo_test.go", `package foo
+
+func TestFoo(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {})
+}
+
+func helper() {}
+
+func (s *suite) TestMethod() {}
+`)
+		r := NewResolver(root)
+
+		t.Run("resolves package directory", func(t *testing.T) {
+			assertResolved(t, "internal/foo")(r.PackageDir("example.com/app/internal/foo"))
+		})
+
+		t.Run("resolves module root package", func(t *testing.T) {
+			assertResolved(t, ".")(r.PackageDir("example.com/app"))
+		})
+
+		t.Run("does not resolve packages of other modules", func(t *testing.T) {
+			assertUnresolved(t)(r.PackageDir("example.com/application"))
+		})
+
+		t.Run("does not resolve missing directories", func(t *testing.T) {
+			assertUnresolved(t)(r.PackageDir("example.com/app/internal/missing"))
+		})
+
+		t.Run("does not resolve command-line-arguments", func(t *testing.T) {
+			assertUnresolved(t)(r.PackageDir("command-line-arguments"))
+		})
+
+		t.Run("Test locations", func(t *testing.T) {
+			testCases := []struct {
+				name         string
+				pkg          string
+				test         string
+				expectedFile string
+				expectedLine int
+			}{
+				{"finds function declaring the test", "example.com/app/internal/foo", "TestFoo", "internal/foo/foo_test.go", 3},
+				{"locates subtests at their top-level test", "example.com/app/internal/foo", "TestFoo/sub", "internal/foo/foo_test.go", 3},
+				{"locates nested subtests at their top-level test", "example.com/app/internal/foo", "TestFoo/sub/deeper", "internal/foo/foo_test.go", 3},
+				{"finds test in module root package", "example.com/app", "TestRoot", "app_test.go", 3},
+			}
+
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					file, line, ok := r.TestLocation(tc.pkg, tc.test)
+					if !ok || file != tc.expectedFile || line != tc.expectedLine {
+						t.Errorf("Expected %s:%d, got %s:%d (resolved: %v)", tc.expectedFile, tc.expectedLine, file, line, ok)
+					}
+				})
+			}
+
+			t.Run("ignores methods", func(t *testing.T) {
+				assertNoLocation(t)(r.TestLocation("example.com/app/internal/foo", "TestMethod"))
+			})
+
+			t.Run("does not find undeclared test", func(t *testing.T) {
+				assertNoLocation(t)(r.TestLocation("example.com/app/internal/foo", "TestMissing"))
+			})
+
+			t.Run("does not find tests of unknown packages", func(t *testing.T) {
+				assertNoLocation(t)(r.TestLocation("example.com/other", "TestFoo"))
+			})
+		})
+	})
+
+	t.Run("Nested modules", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n")
+		writeFile(t, root, "tools/go.mod", "module example.com/app/tools\n")
+		os.MkdirAll(filepath.Join(root, "tools", "gen"), 0755)
+		writeFile(t, root, "vendor/example.com/dep/go.mod", "module example.com/dep\n")
+		r := NewResolver(root)
+
+		t.Run("prefers the nested module", func(t *testing.T) {
+			assertResolved(t, "tools/gen")(r.PackageDir("example.com/app/tools/gen"))
+		})
+
+		t.Run("skips vendor directories", func(t *testing.T) {
+			assertUnresolved(t)(r.PackageDir("example.com/dep"))
+		})
+	})
+
+	t.Run("Finding packages by name", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n")
+		writeFile(t, root, "app_test.go", "package app\n")
+		writeFile(t, root, "internal/calc/calc_test.go", "package calc\n")
+		writeFile(t, root, "internal/store/store.go", "package store\n")
+		writeFile(t, root, "api/store/store_test.go", "package store\n")
+		writeFile(t, root, "cmd/store/store_test.go", "package store\n")
+		writeFile(t, root, "testdata/calc/calc_test.go", "package calc\n")
+		r := NewResolver(root)
+
+		t.Run("finds the package with tests named like the binary", func(t *testing.T) {
+			if pkg, ok := r.FindPackage("calc"); !ok || pkg != "example.com/app/internal/calc" {
+				t.Errorf("Expected example.com/app/internal/calc, got %q", pkg)
+			}
+		})
+
+		t.Run("finds the module root package", func(t *testing.T) {
+			root := t.TempDir()
+			writeFile(t, root, "app/go.mod", "module example.com/app\n")
+			writeFile(t, root, "app/app_test.go", "package app\n")
+
+			if pkg, ok := NewResolver(root).FindPackage("app"); !ok || pkg != "example.com/app" {
+				t.Errorf("Expected example.com/app, got %q", pkg)
+			}
+		})
+
+		t.Run("finds a module root in a directory named unlike its module", func(t *testing.T) {
+			root := t.TempDir()
+			writeFile(t, root, "app-main/go.mod", "module example.com/app\n")
+			writeFile(t, root, "app-main/app_test.go", "package app\n")
+			r := NewResolver(root)
+
+			if pkg, ok := r.FindPackage("app"); !ok || pkg != "example.com/app" {
+				t.Errorf("Expected example.com/app, got %q", pkg)
+			}
+			if pkg, ok := r.FindPackage("app-main"); ok {
+				t.Errorf("Expected no package for the directory name, got %q", pkg)
+			}
+		})
+
+		t.Run("finds a package of a major version module", func(t *testing.T) {
+			root := t.TempDir()
+			writeFile(t, root, "go.mod", "module example.com/calc/v2\n")
+			writeFile(t, root, "calc_test.go", "package calc\n")
+
+			if pkg, ok := NewResolver(root).FindPackage("calc"); !ok || pkg != "example.com/calc/v2" {
+				t.Errorf("Expected example.com/calc/v2, got %q", pkg)
+			}
+		})
+
+		t.Run("does not guess between packages sharing a name", func(t *testing.T) {
+			if pkg, ok := r.FindPackage("store"); ok {
+				t.Errorf("Expected no package, got %q", pkg)
+			}
+		})
+	})
+
+	t.Run("Binary names", func(t *testing.T) {
+		testCases := []struct {
+			pkg      string
+			expected string
+		}{
+			{"example.com/calc", "calc"},
+			{"example.com/calc/v2", "calc"},
+			{"example.com/api/v10/store", "store"},
+			{"v2", "v2"},
+		}
+
+		for _, tc := range testCases {
+			if got := BinaryName(tc.pkg); got != tc.expected {
+				t.Errorf("Expected %q for %s, got %q", tc.expected, tc.pkg, got)
+			}
+		}
+	})
+
+	t.Run("Finding packages by directory", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n")
+		writeFile(t, root, "tools/go.mod", "module example.com/tools\n")
+		r := NewResolver(root)
+
+		for _, tc := range []struct{ dir, pkg string }{
+			{".", "example.com/app"},
+			{"internal/calc", "example.com/app/internal/calc"},
+			{"tools/lint", "example.com/tools/lint"},
+		} {
+			t.Run("finds "+tc.pkg, func(t *testing.T) {
+				if pkg, ok := r.PackageAt(filepath.Join(root, tc.dir)); !ok || pkg != tc.pkg {
+					t.Errorf("Expected %s, got %q", tc.pkg, pkg)
+				}
+			})
+		}
+
+		t.Run("does not find directories outside the modules", func(t *testing.T) {
+			if pkg, ok := r.PackageAt(filepath.Dir(root)); ok {
+				t.Errorf("Expected no package, got %q", pkg)
+			}
+		})
+	})
+
+	t.Run("Workspace", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "go.work", `go 1.24
+
+use (
+	./api // service
+	"./worker"
+)
+
+use ./shared
+`)
+		writeFile(t, root, "api/go.mod", "module example.com/api\n")
+		writeFile(t, root, "worker/go.mod", "module example.com/worker\n")
+		writeFile(t, root, "shared/go.mod", "module example.com/shared\n")
+		writeFile(t, root, "unused/go.mod", "module example.com/unused\n")
+		r := NewResolver(root)
+
+		for _, tc := range []struct{ pkg, dir string }{
+			{"example.com/api", "api"},
+			{"example.com/worker", "worker"},
+			{"example.com/shared", "shared"},
+		} {
+			t.Run("resolves "+tc.pkg, func(t *testing.T) {
+				assertResolved(t, tc.dir)(r.PackageDir(tc.pkg))
+			})
+		}
+
+		t.Run("ignores modules outside the workspace", func(t *testing.T) {
+			assertUnresolved(t)(r.PackageDir("example.com/unused"))
+		})
+
+		t.Run("lists workspace modules by directory", func(t *testing.T) {
+			var paths []string
+			for _, m := range FindModules(root) {
+				paths = append(paths, m.Path)
+			}
+			expected := []string{"example.com/api", "example.com/shared", "example.com/worker"}
+			if !reflect.DeepEqual(paths, expected) {
+				t.Errorf("Expected %v, got %v", expected, paths)
+			}
+		})
+	})
+}
+
+// Helper functions
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertResolved(t *testing.T, expected string) func(string, bool) {
+	t.Helper()
+	return func(got string, ok bool) {
+		t.Helper()
+		if !ok || got != expected {
+			t.Errorf("Expected %q, got %q (resolved: %v)", expected, got, ok)
+		}
+	}
+}
+
+func assertUnresolved(t *testing.T) func(string, bool) {
+	t.Helper()
+	return func(got string, ok bool) {
+		t.Helper()
+		if ok {
+			t.Errorf("Expected no result, got %q", got)
+		}
+	}
+}
+
+func assertNoLocation(t *testing.T) func(string, int, bool) {
+	t.Helper()
+	return func(file string, line int, ok bool) {
+		t.Helper()
+		if ok {
+			t.Errorf("Expected no location, got %s:%d", file, line)
+		}
+	}
+}
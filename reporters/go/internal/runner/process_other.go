@@ -0,0 +1,8 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+// killGroupOnCancel keeps the default of killing only the go command
+func killGroupOnCancel(cmd *exec.Cmd) {}
@@ -0,0 +1,164 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/resolver"
+)
+
+// ModuleErrorTest is the synthetic test reported for a module whose
+// go test run failed without reporting any package
+const ModuleErrorTest = "ModuleError"
+
+// cancelWaitDelay bounds how long a cancelled run waits for its output
+const cancelWaitDelay = time.Second
+
+// Result holds the output of go test in one module
+type Result struct {
+	Module resolver.Module
+	Output []byte
+	Err    error
+}
+
+// Job runs go test with its arguments in a module
+type Job struct {
+	Module resolver.Module
+	Args   []string
+}
+
+// Runner runs go test -json in several modules
+type Runner struct {
+	goBinary string
+	jobs     int
+}
+
+// NewRunner creates a runner using the given go command
+func NewRunner(goBinary string) *Runner {
+	return &Runner{goBinary: goBinary, jobs: 1}
+}
+
+// SetJobs sets how many modules are tested in parallel
+func (r *Runner) SetJobs(jobs int) {
+	if jobs < 1 {
+		jobs = 1
+	}
+	r.jobs = jobs
+}
+
+// Run executes go test -json with the same args in each module directory.
+// Results are returned in module order; a failure in one module does not
+// stop the others.
+func (r *Runner) Run(ctx context.Context, modules []resolver.Module, args []string) []Result {
+	jobs := make([]Job, len(modules))
+	for i, m := range modules {
+		jobs[i] = Job{Module: m, Args: args}
+	}
+	return r.RunJobs(ctx, jobs)
+}
+
+// RunJobs executes each job, returning results in job order
+func (r *Runner) RunJobs(ctx context.Context, jobs []Job) []Result {
+	results := make([]Result, len(jobs))
+	slots := make(chan struct{}, r.jobs)
+	var wg sync.WaitGroup
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = r.runJob(ctx, job)
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// runJob runs go test in a single module, capturing stdout and stderr
+func (r *Runner) runJob(ctx context.Context, job Job) Result {
+	cmd := exec.CommandContext(ctx, r.goBinary, append([]string{"test", "-json"}, job.Args...)...)
+	cmd.Dir = job.Module.Dir
+	cmd.WaitDelay = cancelWaitDelay
+	killGroupOnCancel(cmd)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+
+	return Result{Module: job.Module, Output: output.Bytes(), Err: err}
+}
+
+// Combine joins the output of every module into a single go test -json
+// stream. A module that failed without reporting any package, for example
+// because its go.mod is broken, gets a failing ModuleError test carrying
+// its output, so the failure shows up in the results.
+func Combine(results []Result) []byte {
+	var combined bytes.Buffer
+
+	for _, result := range results {
+		combined.Write(result.Output)
+		if len(result.Output) > 0 && result.Output[len(result.Output)-1] != '\n' {
+			combined.WriteByte('\n')
+		}
+
+		if result.Err != nil && !reportsPackage(result.Output) {
+			writeModuleError(&combined, result)
+		}
+	}
+	return combined.Bytes()
+}
+
+// Failed checks if go test failed in any module
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// reportsPackage checks if the output contains any event for a package
+func reportsPackage(output []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		var event parser.TestEvent
+		if json.Unmarshal(scanner.Bytes(), &event) == nil && (event.Package != "" || event.ImportPath != "") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeModuleError appends events for a failing ModuleError test
+func writeModuleError(w *bytes.Buffer, result Result) {
+	message := strings.TrimSpace(string(result.Output))
+	if message == "" {
+		message = result.Err.Error()
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.Encode(parser.TestEvent{
+		Action:  "output",
+		Package: result.Module.Path,
+		Test:    ModuleErrorTest,
+		Output:  message + "\n",
+	})
+	encoder.Encode(parser.TestEvent{
+		Action:  "fail",
+		Package: result.Module.Path,
+		Test:    ModuleErrorTest,
+	})
+}
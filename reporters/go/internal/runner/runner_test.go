@@ -0,0 +1,133 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/resolver"
+)
+
+func TestRunner(t *testing.T) {
+	t.Run("Run", func(t *testing.T) {
+		modules := []resolver.Module{
+			{Path: "example.com/a", Dir: t.TempDir()},
+			{Path: "example.com/b", Dir: t.TempDir()},
+			{Path: "example.com/c", Dir: t.TempDir()},
+		}
+		r := NewRunner("tdd-guard-go-missing-binary")
+		r.SetJobs(2)
+
+		results := r.Run(context.Background(), modules, nil)
+
+		t.Run("returns results in module order", func(t *testing.T) {
+			for i, result := range results {
+				if result.Module != modules[i] {
+					t.Errorf("Expected result %d for %s, got %s", i, modules[i].Path, result.Module.Path)
+				}
+			}
+		})
+
+		t.Run("reports failure of every module", func(t *testing.T) {
+			for _, result := range results {
+				if result.Err == nil {
+					t.Errorf("Expected error for %s", result.Module.Path)
+				}
+			}
+		})
+	})
+
+	t.Run("stops running jobs when cancelled", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("requires a shell script")
+		}
+		script := filepath.Join(t.TempDir(), "go")
+		os.WriteFile(script, []byte("#!/bin/sh\nsleep 30 &\nwait\n"), 0755)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		results := NewRunner(script).RunJobs(ctx, []Job{{Module: resolver.Module{Dir: t.TempDir()}}})
+
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("Expected cancelled job to stop, took %v", elapsed)
+		}
+		if results[0].Err == nil {
+			t.Error("Expected error for cancelled job")
+		}
+	})
+
+	t.Run("Combine", func(t *testing.T) {
+		passing := Result{
+			Module: resolver.Module{Path: "example.com/a"},
+			Output: []byte(`{"Action":"pass","Package":"example.com/a","Test":"TestA"}`),
+		}
+		failing := Result{
+			Module: resolver.Module{Path: "example.com/b"},
+			Output: []byte(`{"Action":"fail","Package":"example.com/b","Test":"TestB"}` + "\n"),
+			Err:    errors.New("exit status 1"),
+		}
+		broken := Result{
+			Module: resolver.Module{Path: "example.com/c"},
+			Output: []byte("go: errors parsing go.mod\n"),
+			Err:    errors.New("exit status 1"),
+		}
+
+		results := parseCombined(t, Combine([]Result{passing, failing, broken}))
+
+		t.Run("keeps results of every module", func(t *testing.T) {
+			if results["example.com/a"]["TestA"] != parser.StatePassed {
+				t.Errorf("Expected passing test of first module, got %v", results)
+			}
+			if results["example.com/b"]["TestB"] != parser.StateFailed {
+				t.Errorf("Expected failing test of second module, got %v", results)
+			}
+		})
+
+		t.Run("adds ModuleError for modules without package results", func(t *testing.T) {
+			if results["example.com/c"][ModuleErrorTest] != parser.StateFailed {
+				t.Errorf("Expected ModuleError for broken module, got %v", results)
+			}
+		})
+
+		t.Run("does not add ModuleError for failing tests", func(t *testing.T) {
+			if _, exists := results["example.com/b"][ModuleErrorTest]; exists {
+				t.Error("Expected no ModuleError for module with test results")
+			}
+		})
+
+		t.Run("includes module output in ModuleError", func(t *testing.T) {
+			p := parser.NewParser()
+			p.Parse(strings.NewReader(string(Combine([]Result{broken}))))
+			if output := p.GetTestOutput("example.com/c", ModuleErrorTest); output != "go: errors parsing go.mod" {
+				t.Errorf("Expected module output, got %q", output)
+			}
+		})
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		if Failed([]Result{{}, {}}) {
+			t.Error("Expected no failure")
+		}
+		if !Failed([]Result{{}, {Err: errors.New("exit status 1")}}) {
+			t.Error("Expected failure")
+		}
+	})
+}
+
+// Helper functions
+
+func parseCombined(t *testing.T, output []byte) parser.Results {
+	t.Helper()
+	p := parser.NewParser()
+	if err := p.Parse(strings.NewReader(string(output))); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return p.GetResults()
+}
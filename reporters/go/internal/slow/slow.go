@@ -0,0 +1,162 @@
+package slow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/filter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// PackageTest is the synthetic test failing a package over its budget in strict mode
+const PackageTest = "SlowPackage"
+
+// Thresholds decides how long tests may take before they count as slow
+type Thresholds struct {
+	global   time.Duration
+	packages []packageLimit
+}
+
+// Budgets decides how long packages may take in total
+type Budgets struct {
+	packages []packageLimit
+}
+
+// packageLimit applies a limit to matching packages
+type packageLimit struct {
+	pattern *regexp.Regexp
+	limit   time.Duration
+}
+
+// NewThresholds creates thresholds from a global limit and per-package
+// entries of the form "pattern=duration". Patterns use the package filter
+// syntax and the first matching entry wins. A zero limit disables detection.
+func NewThresholds(global time.Duration, perPackage []string) (*Thresholds, error) {
+	packages, err := parseLimits("slow threshold", perPackage)
+	if err != nil {
+		return nil, err
+	}
+	return &Thresholds{global: global, packages: packages}, nil
+}
+
+// For returns the threshold of tests in the package, 0 when there is none
+func (t *Thresholds) For(pkg string) time.Duration {
+	if limit, ok := lookup(t.packages, pkg); ok {
+		return limit
+	}
+	return t.global
+}
+
+// Mark flags tests that took longer than the threshold of their package as
+// slow and returns them. In strict mode slow tests also fail, with an error
+// naming the threshold they exceeded.
+func (t *Thresholds) Mark(result *transformer.TestResult, strict bool) []transformer.Test {
+	var slow []transformer.Test
+	for i := range result.TestModules {
+		module := &result.TestModules[i]
+		limit := t.For(module.Package)
+		if limit <= 0 {
+			continue
+		}
+
+		for j := range module.Tests {
+			test := &module.Tests[j]
+			if test.Name == parser.CompilationErrorTest || test.Duration <= limit {
+				continue
+			}
+			test.Slow = true
+			if strict {
+				fail(result, test, fmt.Sprintf("test took %s, exceeding the slow threshold of %s", test.Duration.Round(time.Millisecond), limit))
+			}
+			slow = append(slow, *test)
+		}
+	}
+	return slow
+}
+
+// NewBudgets creates budgets for the total time of packages from entries of
+// the form "pattern=duration", matched like the entries of NewThresholds
+func NewBudgets(entries []string) (*Budgets, error) {
+	packages, err := parseLimits("slow budget", entries)
+	if err != nil {
+		return nil, err
+	}
+	return &Budgets{packages: packages}, nil
+}
+
+// For returns the time the package may take in total, 0 when it has no budget
+func (b *Budgets) For(pkg string) time.Duration {
+	limit, _ := lookup(b.packages, pkg)
+	return limit
+}
+
+// Mark warns about packages that took longer than their budget. In strict
+// mode such a package gets a failed slow PackageTest instead, and the added
+// tests are returned.
+func (b *Budgets) Mark(result *transformer.TestResult, strict bool) []transformer.Test {
+	var slow []transformer.Test
+	for i := range result.TestModules {
+		module := &result.TestModules[i]
+		limit := b.For(module.Package)
+		if limit <= 0 || module.Duration <= limit {
+			continue
+		}
+
+		message := fmt.Sprintf("package took %s, exceeding its slow budget of %s", module.Duration.Round(time.Millisecond), limit)
+		if !strict {
+			result.Warnings = append(result.Warnings, module.Package+": "+message)
+			continue
+		}
+		test := transformer.Test{
+			Name:     PackageTest,
+			FullName: module.Package + "/" + PackageTest,
+			Slow:     true,
+			Duration: module.Duration,
+		}
+		fail(result, &test, message)
+		module.Tests = append(module.Tests, test)
+		slow = append(slow, test)
+	}
+	return slow
+}
+
+// parseLimits parses "pattern=duration" entries, naming the setting in errors
+func parseLimits(setting string, entries []string) ([]packageLimit, error) {
+	var limits []packageLimit
+	for _, entry := range entries {
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%s %q: expected pattern=duration", setting, entry)
+		}
+		pattern, err := filter.CompilePattern(entry[:i])
+		if err != nil {
+			return nil, fmt.Errorf("%s %q: %w", setting, entry, err)
+		}
+		limit, err := time.ParseDuration(entry[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("%s %q: %w", setting, entry, err)
+		}
+		limits = append(limits, packageLimit{pattern: pattern, limit: limit})
+	}
+	return limits, nil
+}
+
+// lookup returns the limit of the first entry matching the package
+func lookup(limits []packageLimit, pkg string) (time.Duration, bool) {
+	for _, l := range limits {
+		if l.pattern.MatchString(pkg) {
+			return l.limit, true
+		}
+	}
+	return 0, false
+}
+
+// fail marks the test and the result failed with the message
+func fail(result *transformer.TestResult, test *transformer.Test, message string) {
+	test.State = "failed"
+	test.Errors = append(test.Errors, transformer.TestError{Message: message})
+	result.Reason = "failed"
+}
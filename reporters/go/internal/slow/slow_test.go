@@ -0,0 +1,144 @@
+package slow
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestThresholds(t *testing.T) {
+	t.Run("For", func(t *testing.T) {
+		thresholds, err := NewThresholds(time.Second, []string{"example.com/app/e2e/...=1m", "**/db=5s"})
+		if err != nil {
+			t.Fatalf("NewThresholds failed: %v", err)
+		}
+
+		tests := []struct {
+			pkg      string
+			expected time.Duration
+		}{
+			{"example.com/app/calc", time.Second},
+			{"example.com/app/e2e/checkout", time.Minute},
+			{"example.com/app/store/db", 5 * time.Second},
+		}
+		for _, tt := range tests {
+			t.Run(tt.pkg, func(t *testing.T) {
+				if got := thresholds.For(tt.pkg); got != tt.expected {
+					t.Errorf("Expected %v, got %v", tt.expected, got)
+				}
+			})
+		}
+	})
+
+	t.Run("rejects invalid entries", func(t *testing.T) {
+		for _, entry := range []string{"example.com/app", "example.com/app=soon", "re:(=1s"} {
+			if _, err := NewThresholds(0, []string{entry}); err == nil {
+				t.Errorf("Expected error for %q", entry)
+			}
+		}
+	})
+
+	t.Run("Mark", func(t *testing.T) {
+		thresholds, _ := NewThresholds(time.Second, []string{"example.com/app/e2e=0s"})
+
+		t.Run("flags tests exceeding their package threshold", func(t *testing.T) {
+			result := sampleResult()
+
+			slow := thresholds.Mark(result, false)
+
+			if len(slow) != 1 || slow[0].Name != "TestSlow" {
+				t.Fatalf("Expected TestSlow to be slow, got %+v", slow)
+			}
+			tests := result.TestModules[0].Tests
+			if !tests[1].Slow || tests[0].Slow || tests[1].State != "passed" {
+				t.Errorf("Expected only TestSlow flagged and still passing, got %+v", tests)
+			}
+		})
+
+		t.Run("skips packages with a zero threshold", func(t *testing.T) {
+			result := sampleResult()
+			thresholds.Mark(result, false)
+
+			if result.TestModules[1].Tests[0].Slow {
+				t.Error("Expected no slow tests in package without threshold")
+			}
+		})
+
+		t.Run("fails slow tests in strict mode", func(t *testing.T) {
+			result := sampleResult()
+
+			thresholds.Mark(result, true)
+
+			test := result.TestModules[0].Tests[1]
+			if test.State != "failed" || result.Reason != "failed" {
+				t.Errorf("Expected failed test and result, got %s and %s", test.State, result.Reason)
+			}
+			if len(test.Errors) != 1 || !strings.Contains(test.Errors[0].Message, "exceeding the slow threshold of 1s") {
+				t.Errorf("Expected threshold error, got %+v", test.Errors)
+			}
+		})
+	})
+}
+
+func TestBudgets(t *testing.T) {
+	t.Run("rejects invalid entries", func(t *testing.T) {
+		if _, err := NewBudgets([]string{"example.com/app"}); err == nil || !strings.Contains(err.Error(), "slow budget") {
+			t.Errorf("Expected slow budget error, got %v", err)
+		}
+	})
+
+	t.Run("Mark", func(t *testing.T) {
+		budgets, _ := NewBudgets([]string{"example.com/app/calc=1s", "example.com/app/e2e=0s"})
+
+		t.Run("warns about packages over their budget", func(t *testing.T) {
+			result := sampleResult()
+
+			slow := budgets.Mark(result, false)
+
+			expected := []string{"example.com/app/calc: package took 1.51s, exceeding its slow budget of 1s"}
+			if !reflect.DeepEqual(result.Warnings, expected) {
+				t.Errorf("Expected %q, got %q", expected, result.Warnings)
+			}
+			if len(slow) != 0 || len(result.TestModules[0].Tests) != 2 || result.Reason != "passed" {
+				t.Errorf("Expected the package to keep passing, got %+v", result)
+			}
+		})
+
+		t.Run("fails packages over their budget in strict mode", func(t *testing.T) {
+			result := sampleResult()
+
+			slow := budgets.Mark(result, true)
+
+			tests := result.TestModules[0].Tests
+			if len(slow) != 1 || len(tests) != 3 || tests[2].FullName != "example.com/app/calc/SlowPackage" {
+				t.Fatalf("Expected a SlowPackage test, got %+v", tests)
+			}
+			if tests[2].State != "failed" || !tests[2].Slow || result.Reason != "failed" {
+				t.Errorf("Expected failed slow package test, got %+v", tests[2])
+			}
+			if len(result.Warnings) != 0 {
+				t.Errorf("Expected no warnings, got %q", result.Warnings)
+			}
+		})
+	})
+}
+
+// Helper functions
+
+func sampleResult() *transformer.TestResult {
+	return &transformer.TestResult{
+		Reason: "passed",
+		TestModules: []transformer.TestModule{
+			{Package: "example.com/app/calc", Duration: 1510 * time.Millisecond, Tests: []transformer.Test{
+				{Name: "TestFast", State: "passed", Duration: 10 * time.Millisecond},
+				{Name: "TestSlow", State: "passed", Duration: 1500 * time.Millisecond},
+			}},
+			{Package: "example.com/app/e2e", Duration: time.Minute, Tests: []transformer.Test{
+				{Name: "TestCheckout", State: "passed", Duration: time.Minute},
+			}},
+		},
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package storage
+
+import "os"
+
+// tryLock always succeeds on platforms without file locking support
+func tryLock(f *os.File) (bool, error) {
+	return true, nil
+}
+
+// unlock is a no-op on platforms without file locking support
+func unlock(f *os.File) error {
+	return nil
+}
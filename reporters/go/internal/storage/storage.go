@@ -2,8 +2,11 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
 )
@@ -13,23 +16,164 @@ var (
 	TestResultsPath = []string{".claude", "tdd-guard", "data", "test.json"}
 )
 
+const (
+	// DefaultLockTimeout is how long Save waits for another writer to finish
+	DefaultLockTimeout = 10 * time.Second
+
+	lockRetryInterval = 25 * time.Millisecond
+)
+
+// ErrLockTimeout is returned when the results file stays locked by another writer
+var ErrLockTimeout = errors.New("timed out waiting for lock")
+
 type Storage struct {
-	basePath string
+	path        string
+	lockTimeout time.Duration
 }
 
 func NewStorage(projectRoot string) *Storage {
-	return &Storage{basePath: projectRoot}
+	parts := append([]string{projectRoot}, TestResultsPath...)
+	return &Storage{path: filepath.Join(parts...), lockTimeout: DefaultLockTimeout}
+}
+
+// NewStorageInDir creates storage that saves test results in dataDir
+// instead of the project's default data directory
+func NewStorageInDir(dataDir string) *Storage {
+	fileName := TestResultsPath[len(TestResultsPath)-1]
+	return &Storage{path: filepath.Join(dataDir, fileName), lockTimeout: DefaultLockTimeout}
 }
 
+// SetLockTimeout changes how long writes wait for the lock
+func (s *Storage) SetLockTimeout(timeout time.Duration) {
+	s.lockTimeout = timeout
+}
+
+// Save atomically replaces the saved results while holding the lock
 func (s *Storage) Save(results *transformer.TestResult) error {
-	parts := append([]string{s.basePath}, TestResultsPath...)
-	filePath := filepath.Join(parts...)
+	return s.Update(func(*transformer.TestResult) (*transformer.TestResult, error) {
+		return results, nil
+	})
+}
+
+// Update replaces the saved results with the value returned by update.
+// The lock is held from reading the previous results until the new results
+// are in place, so concurrent writers cannot lose each other's updates.
+// A previous file that cannot be decoded is passed to update as nil.
+func (s *Storage) Update(update func(previous *transformer.TestResult) (*transformer.TestResult, error)) error {
+	filePath := s.path
 
 	// Ensure directory exists
 	dir := filepath.Dir(filePath)
-	os.MkdirAll(dir, 0755)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+
+	release, err := s.lock(filePath + ".lock")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	previous, err := s.Load()
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if err != nil && !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr) {
+		return fmt.Errorf("read previous results: %w", err)
+	}
+
+	results, err := update(previous)
+	if err != nil {
+		return err
+	}
 
 	// Marshal to JSON
-	data, _ := json.Marshal(results)
-	return os.WriteFile(filePath, data, 0644)
+	data, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("encode results: %w", err)
+	}
+	return writeAtomic(filePath, data)
+}
+
+// Load reads the previously saved results.
+// Returns nil without error when no results have been saved yet.
+func (s *Storage) Load() (*transformer.TestResult, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var results *transformer.TestResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Path returns the location of the results file
+func (s *Storage) Path() string {
+	return s.path
+}
+
+// lock takes an advisory lock on lockPath, retrying until the timeout expires
+func (s *Storage) lock(lockPath string) (func(), error) {
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(s.lockTimeout)
+	for {
+		locked, err := tryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("lock %s: %w", lockPath, err)
+		}
+		if locked {
+			return func() {
+				unlock(f)
+				f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("lock %s: %w", lockPath, ErrLockTimeout)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// writeAtomic writes data to a temporary file in the same directory,
+// flushes it to disk and renames it over filePath, so readers only ever
+// see the previous or the new complete file
+func writeAtomic(filePath string, data []byte) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("sync results: %w", err)
+	}
+	if err = tmp.Chmod(0644); err != nil {
+		return fmt.Errorf("set results permissions: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close results: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("replace results: %w", err)
+	}
+	return nil
 }
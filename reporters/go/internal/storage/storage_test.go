@@ -1,9 +1,14 @@
 package storage
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
 )
 
 func TestStorage(t *testing.T) {
@@ -23,6 +28,25 @@ func TestStorage(t *testing.T) {
 		})
 	})
 
+	t.Run("NewStorageInDir", func(t *testing.T) {
+		t.Run("saves results in the given directory", func(t *testing.T) {
+			dataDir := filepath.Join(t.TempDir(), "results")
+			storage := NewStorageInDir(dataDir)
+			storage.Save(nil)
+
+			if _, err := os.Stat(filepath.Join(dataDir, "test.json")); err != nil {
+				t.Fatalf("Expected results file in data directory, got: %v", err)
+			}
+		})
+
+		t.Run("reports the results path", func(t *testing.T) {
+			dataDir := filepath.Join(t.TempDir(), "results")
+			if got := NewStorageInDir(dataDir).Path(); got != filepath.Join(dataDir, "test.json") {
+				t.Errorf("Expected path in data directory, got %q", got)
+			}
+		})
+	})
+
 	t.Run("Save", func(t *testing.T) {
 		// Setup: Create temp directory and change to it for all tests
 		oldWd, _ := os.Getwd()
@@ -110,4 +134,177 @@ func TestStorage(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("Load", func(t *testing.T) {
+		t.Run("returns nil when no results exist", func(t *testing.T) {
+			storage := NewStorage(t.TempDir())
+
+			results, err := storage.Load()
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if results != nil {
+				t.Fatalf("Expected nil results, got %+v", results)
+			}
+		})
+
+		t.Run("returns previously saved results", func(t *testing.T) {
+			storage := NewStorage(t.TempDir())
+			saved := &transformer.TestResult{
+				TestModules: []transformer.TestModule{{ModuleID: "example.com/pkg"}},
+				Reason:      "passed",
+			}
+			storage.Save(saved)
+
+			results, err := storage.Load()
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if results == nil || len(results.TestModules) != 1 || results.TestModules[0].ModuleID != "example.com/pkg" {
+				t.Fatalf("Expected saved results, got %+v", results)
+			}
+		})
+
+		t.Run("returns error for malformed file", func(t *testing.T) {
+			projectDir := t.TempDir()
+			storage := NewStorage(projectDir)
+			storage.Save(nil)
+
+			parts := append([]string{projectDir}, TestResultsPath...)
+			os.WriteFile(filepath.Join(parts...), []byte("{truncated"), 0644)
+
+			if _, err := storage.Load(); err == nil {
+				t.Fatal("Expected error for malformed file")
+			}
+		})
+	})
+
+	t.Run("Atomic writes", func(t *testing.T) {
+		t.Run("leaves no temporary files behind", func(t *testing.T) {
+			projectDir := t.TempDir()
+			storage := NewStorage(projectDir)
+			storage.Save(&transformer.TestResult{Reason: "passed"})
+
+			entries, _ := os.ReadDir(resultsDir(projectDir))
+			for _, entry := range entries {
+				if strings.HasSuffix(entry.Name(), ".tmp") {
+					t.Fatalf("Expected no temporary files, found %s", entry.Name())
+				}
+			}
+		})
+
+		t.Run("replaces previous content completely", func(t *testing.T) {
+			projectDir := t.TempDir()
+			storage := NewStorage(projectDir)
+			storage.Save(&transformer.TestResult{TestModules: []transformer.TestModule{{ModuleID: strings.Repeat("x", 1000)}}})
+			storage.Save(&transformer.TestResult{Reason: "passed"})
+
+			results, err := storage.Load()
+			if err != nil {
+				t.Fatalf("Expected readable results, got: %v", err)
+			}
+			if results.Reason != "passed" || len(results.TestModules) != 0 {
+				t.Fatalf("Expected latest results, got %+v", results)
+			}
+		})
+
+		t.Run("returns error when directory cannot be created", func(t *testing.T) {
+			projectDir := t.TempDir()
+			os.WriteFile(filepath.Join(projectDir, ".claude"), []byte("not a directory"), 0644)
+
+			if err := NewStorage(projectDir).Save(nil); err == nil {
+				t.Fatal("Expected error when data directory cannot be created")
+			}
+		})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		t.Run("passes previous results", func(t *testing.T) {
+			storage := NewStorage(t.TempDir())
+			storage.Save(&transformer.TestResult{Reason: "failed"})
+
+			var received *transformer.TestResult
+			storage.Update(func(previous *transformer.TestResult) (*transformer.TestResult, error) {
+				received = previous
+				return previous, nil
+			})
+
+			if received == nil || received.Reason != "failed" {
+				t.Fatalf("Expected previous results, got %+v", received)
+			}
+		})
+
+		t.Run("passes nil for malformed previous results", func(t *testing.T) {
+			projectDir := t.TempDir()
+			storage := NewStorage(projectDir)
+			storage.Save(nil)
+			os.WriteFile(filepath.Join(resultsDir(projectDir), "test.json"), []byte("{truncated"), 0644)
+
+			err := storage.Update(func(previous *transformer.TestResult) (*transformer.TestResult, error) {
+				if previous != nil {
+					t.Errorf("Expected nil previous results, got %+v", previous)
+				}
+				return previous, nil
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		})
+
+		t.Run("returns error from update without writing", func(t *testing.T) {
+			storage := NewStorage(t.TempDir())
+			storage.Save(&transformer.TestResult{Reason: "passed"})
+			updateErr := errors.New("update failed")
+
+			err := storage.Update(func(*transformer.TestResult) (*transformer.TestResult, error) {
+				return &transformer.TestResult{Reason: "failed"}, updateErr
+			})
+			if !errors.Is(err, updateErr) {
+				t.Fatalf("Expected update error, got: %v", err)
+			}
+
+			results, _ := storage.Load()
+			if results.Reason != "passed" {
+				t.Fatalf("Expected results to be unchanged, got %+v", results)
+			}
+		})
+	})
+
+	t.Run("Locking", func(t *testing.T) {
+		t.Run("times out while another writer holds the lock", func(t *testing.T) {
+			projectDir := t.TempDir()
+			storage := NewStorage(projectDir)
+			storage.Save(nil)
+
+			release, err := storage.lock(filepath.Join(resultsDir(projectDir), "test.json.lock"))
+			if err != nil {
+				t.Fatalf("Expected lock, got: %v", err)
+			}
+			defer release()
+
+			blocked := NewStorage(projectDir)
+			blocked.SetLockTimeout(50 * time.Millisecond)
+			if err := blocked.Save(nil); !errors.Is(err, ErrLockTimeout) {
+				t.Fatalf("Expected lock timeout, got: %v", err)
+			}
+		})
+
+		t.Run("waits for the lock to be released", func(t *testing.T) {
+			projectDir := t.TempDir()
+			storage := NewStorage(projectDir)
+			storage.Save(nil)
+
+			release, _ := storage.lock(filepath.Join(resultsDir(projectDir), "test.json.lock"))
+			time.AfterFunc(50*time.Millisecond, release)
+
+			if err := NewStorage(projectDir).Save(nil); err != nil {
+				t.Fatalf("Expected save after lock release, got: %v", err)
+			}
+		})
+	})
+}
+
+func resultsDir(projectDir string) string {
+	dirParts := TestResultsPath[:len(TestResultsPath)-1]
+	return filepath.Join(append([]string{projectDir}, dirParts...)...)
 }
@@ -0,0 +1,53 @@
+package style
+
+// Style selects how go test events are rendered in the terminal
+type Style string
+
+const (
+	// StandardQuiet prints package summaries and failure details
+	StandardQuiet Style = "standard-quiet"
+	// StandardVerbose passes all go test output through
+	StandardVerbose Style = "standard-verbose"
+	// Dots prints a line per package with one character per test
+	Dots Style = "dots"
+	// Testname prints a line per test
+	Testname Style = "testname"
+	// Pkgname prints a line per package with test counts
+	Pkgname Style = "pkgname"
+)
+
+// Styles lists every supported style
+var Styles = []Style{StandardQuiet, StandardVerbose, Dots, Testname, Pkgname}
+
+// ColorMode selects when terminal output is colored
+type ColorMode string
+
+const (
+	// ColorAuto colors output written to a terminal, honoring NO_COLOR and FORCE_COLOR
+	ColorAuto ColorMode = "auto"
+	// ColorAlways colors output regardless of where it goes
+	ColorAlways ColorMode = "always"
+	// ColorNever never colors output
+	ColorNever ColorMode = "never"
+)
+
+// ColorModes lists every supported color mode
+var ColorModes = []ColorMode{ColorAuto, ColorAlways, ColorNever}
+
+// Names returns the names of the styles
+func Names() []string {
+	names := make([]string, len(Styles))
+	for i, s := range Styles {
+		names[i] = string(s)
+	}
+	return names
+}
+
+// ColorNames returns the names of the color modes
+func ColorNames() []string {
+	names := make([]string, len(ColorModes))
+	for i, mode := range ColorModes {
+		names[i] = string(mode)
+	}
+	return names
+}
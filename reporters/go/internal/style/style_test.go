@@ -0,0 +1,24 @@
+package style
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	t.Run("lists the style names", func(t *testing.T) {
+		expected := []string{"standard-quiet", "standard-verbose", "dots", "testname", "pkgname"}
+
+		if names := Names(); !reflect.DeepEqual(names, expected) {
+			t.Errorf("Expected %v, got %v", expected, names)
+		}
+	})
+
+	t.Run("lists the color mode names", func(t *testing.T) {
+		expected := []string{"auto", "always", "never"}
+
+		if names := ColorNames(); !reflect.DeepEqual(names, expected) {
+			t.Errorf("Expected %v, got %v", expected, names)
+		}
+	})
+}
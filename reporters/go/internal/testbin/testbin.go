@@ -0,0 +1,223 @@
+package testbin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/reporter"
+)
+
+// Verbosity modes of the -test.v flag
+const (
+	modeQuiet   = "false"
+	modeVerbose = "true"
+	modeJSON    = "test2json"
+)
+
+var (
+	// frame removes the markers -test.v=test2json adds to the lines test2json
+	// converts to events, and around the output of failures
+	frame = strings.NewReplacer("\x16", "", "\x0f", "", "\x0e", "")
+	// marker matches the line printed before the output of a test
+	marker = regexp.MustCompile(`^=== (RUN|PAUSE|CONT|NAME)\s*(\S*)`)
+	// report matches the line printed when a test ends
+	report = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (\S+)`)
+)
+
+// Run runs the test binary of cmd verbosely to follow its tests and saves
+// their results with r. The output is written to stdout as the
+// binary prints it with its own flags, and the binary's exit code is
+// returned, also when saving the results fails. Listing tests with
+// -test.list reports nothing.
+func Run(cmd *exec.Cmd, r *reporter.Reporter, stdout io.Writer) (int, error) {
+	args := cmd.Args[1:]
+	if _, listing := flagValue(args, "list"); listing {
+		cmd.Stdout, cmd.Stderr = stdout, stdout
+		return ExitCode(cmd.Run())
+	}
+
+	// Verbose output already tells the tests apart, otherwise the markers of
+	// -test.v=test2json attribute output the binary would not print
+	mode, _ := flagValue(args, "v")
+	run := modeJSON
+	if mode == modeVerbose {
+		run = modeVerbose
+	}
+	cmd.Args = append([]string{cmd.Args[0], "-test.v=" + run}, withoutFlag(args, "v")...)
+
+	// go test shows the binary's stdout and stderr as one stream
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return 1, err
+	}
+	defer reader.Close()
+	cmd.Stdout, cmd.Stderr = writer, writer
+
+	started := time.Now()
+	err = cmd.Start()
+	writer.Close()
+	if err != nil {
+		return 1, err
+	}
+
+	var output bytes.Buffer
+	e := newEcho(stdout, mode)
+	lines := bufio.NewReader(reader)
+	for {
+		line, err := lines.ReadString('\n')
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+			e.line(line)
+			output.WriteString(frame.Replace(line) + "\n")
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	code, err := ExitCode(cmd.Wait())
+	if err != nil {
+		return code, err
+	}
+
+	// The binary does not name its package, go test prints that line
+	status := "ok  "
+	if code != 0 {
+		status = "FAIL"
+	}
+	fmt.Fprintf(&output, "%s\t%s\t%.3fs\n", status, r.Package(), time.Since(started).Seconds())
+
+	if err := r.ReadText(&output); err != nil {
+		return code, err
+	}
+	_, err = r.Finish()
+	return code, err
+}
+
+// ExitCode returns the exit code of a finished command, or an error when
+// it could not run
+func ExitCode(err error) (int, error) {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0, nil
+	case errors.As(err, &exitErr):
+		if code := exitErr.ExitCode(); code > 0 {
+			return code, nil
+		}
+		return 1, nil
+	default:
+		return 1, err
+	}
+}
+
+// flagValue returns the value of the test flag name in args, the last one
+// winning. A flag without a value is a boolean set to true.
+func flagValue(args []string, name string) (string, bool) {
+	var value string
+	var found bool
+	for _, arg := range args {
+		key, v, hasValue := strings.Cut(arg, "=")
+		if !isFlag(key, name) {
+			continue
+		}
+		if !hasValue {
+			v = "true"
+		}
+		value, found = v, true
+	}
+	return value, found
+}
+
+// withoutFlag removes the test flag name from args
+func withoutFlag(args []string, name string) []string {
+	var kept []string
+	for _, arg := range args {
+		if key, _, _ := strings.Cut(arg, "="); !isFlag(key, name) {
+			kept = append(kept, arg)
+		}
+	}
+	return kept
+}
+
+// isFlag checks if key names the test flag, with one or two dashes
+func isFlag(key, name string) bool {
+	return key == "-test."+name || key == "--test."+name
+}
+
+// echo prints the output of a binary run with -test.v=test2json the way
+// the binary prints it in mode
+type echo struct {
+	w    io.Writer
+	mode string
+	test string              // Test the following output belongs to
+	held map[string][]string // Output of running tests, printed if they fail
+}
+
+// newEcho creates an echo writing to w in the mode of the -test.v flag
+func newEcho(w io.Writer, mode string) *echo {
+	return &echo{w: w, mode: mode, held: make(map[string][]string)}
+}
+
+// line prints a line of output, or holds it until its test ends when not
+// verbose, since go test then only shows the output of failed tests
+func (e *echo) line(line string) {
+	switch e.mode {
+	case modeJSON:
+		fmt.Fprintln(e.w, line)
+		return
+	case modeVerbose:
+		fmt.Fprintln(e.w, line)
+		return
+	}
+
+	text := frame.Replace(line)
+	if !strings.HasPrefix(line, "\x16") {
+		if e.test == "" {
+			fmt.Fprintln(e.w, text)
+		} else {
+			e.held[e.test] = append(e.held[e.test], text)
+		}
+		return
+	}
+
+	if m := marker.FindStringSubmatch(text); m != nil {
+		e.test = m[2]
+		if m[1] == "PAUSE" {
+			e.test = ""
+		}
+		return
+	}
+
+	if m := report.FindStringSubmatch(text); m != nil {
+		e.test = ""
+		block := append([]string{strings.TrimLeft(text, " ")}, e.held[m[2]]...)
+		delete(e.held, m[2])
+		if m[1] != "FAIL" {
+			return
+		}
+
+		// Failed subtests are shown indented below their parent's result
+		if i := strings.LastIndex(m[2], "/"); i >= 0 {
+			parent := m[2][:i]
+			for _, held := range block {
+				e.held[parent] = append(e.held[parent], "    "+held)
+			}
+			return
+		}
+		for _, held := range block {
+			fmt.Fprintln(e.w, held)
+		}
+		return
+	}
+
+	fmt.Fprintln(e.w, text)
+}
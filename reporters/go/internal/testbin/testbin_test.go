@@ -0,0 +1,219 @@
+package testbin
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/reporter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// helperEnv makes the helper tests run when this binary runs them itself
+const helperEnv = "TDD_GUARD_GO_TESTBIN_HELPER"
+
+func TestRun(t *testing.T) {
+	t.Run("saves the results of the binary", func(t *testing.T) {
+		reporter := newReporter(t)
+		var stdout bytes.Buffer
+
+		code, err := Run(helperCommand("^TestHelperPass$"), reporter, &stdout)
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+
+		result := readSaved(t, reporter)
+		if code != 0 || result.Reason != "passed" || result.TestModules[0].Tests[0].Name != "TestHelperPass" {
+			t.Errorf("Expected passing TestHelperPass, got exit code %d and %+v", code, result)
+		}
+		if stdout.String() != "PASS\n" {
+			t.Errorf("Expected quiet output, got %q", stdout.String())
+		}
+	})
+
+	t.Run("passes through failures and their exit code", func(t *testing.T) {
+		reporter := newReporter(t)
+		var stdout bytes.Buffer
+
+		code, _ := Run(helperCommand("^TestHelperFail$"), reporter, &stdout)
+
+		result := readSaved(t, reporter)
+		if code != 1 || result.Reason != "failed" {
+			t.Errorf("Expected failed run with exit code 1, got %d and %+v", code, result)
+		}
+		if !strings.Contains(stdout.String(), "--- FAIL: TestHelperFail") || !strings.Contains(stdout.String(), "helper failed") {
+			t.Errorf("Expected the failure in the output, got %q", stdout.String())
+		}
+	})
+
+	t.Run("prints the output of verbose runs", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		Run(helperCommand("^TestHelperPass$", "-test.v"), newReporter(t), &stdout)
+
+		expected := "=== RUN   TestHelperPass\n--- PASS: TestHelperPass"
+		if !strings.HasPrefix(stdout.String(), expected) {
+			t.Errorf("Expected verbose output, got %q", stdout.String())
+		}
+	})
+
+	t.Run("keeps the markers for test2json", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		Run(helperCommand("^TestHelperPass$", "-test.v=test2json"), newReporter(t), &stdout)
+
+		if !strings.Contains(stdout.String(), "\x16=== RUN   TestHelperPass\n") {
+			t.Errorf("Expected framed output, got %q", stdout.String())
+		}
+	})
+
+	t.Run("lists tests without reporting", func(t *testing.T) {
+		reporter := newReporter(t)
+		var stdout bytes.Buffer
+
+		Run(helperCommand("", "-test.list=^TestHelperPass$"), reporter, &stdout)
+
+		if stdout.String() != "TestHelperPass\n" {
+			t.Errorf("Expected test list, got %q", stdout.String())
+		}
+		if _, err := os.Stat(reporter.ResultsPath()); err == nil {
+			t.Error("Expected no saved results")
+		}
+	})
+
+	t.Run("returns an error when the binary cannot run", func(t *testing.T) {
+		code, err := Run(exec.Command(filepath.Join(t.TempDir(), "missing.test")), newReporter(t), &bytes.Buffer{})
+
+		if code != 1 || err == nil {
+			t.Errorf("Expected exit code 1 and an error, got %d and %v", code, err)
+		}
+	})
+}
+
+func TestFlags(t *testing.T) {
+	t.Run("finds the last value of a flag", func(t *testing.T) {
+		testCases := []struct {
+			args     []string
+			expected string
+			found    bool
+		}{
+			{[]string{"-test.run=X"}, "", false},
+			{[]string{"-test.v"}, "true", true},
+			{[]string{"--test.v=test2json"}, "test2json", true},
+			{[]string{"-test.v=true", "-test.v=false"}, "false", true},
+		}
+
+		for _, tc := range testCases {
+			value, found := flagValue(tc.args, "v")
+			if value != tc.expected || found != tc.found {
+				t.Errorf("Expected %q (%v) for %v, got %q (%v)", tc.expected, tc.found, tc.args, value, found)
+			}
+		}
+	})
+
+	t.Run("removes a flag", func(t *testing.T) {
+		args := withoutFlag([]string{"-test.v=true", "-test.run=X", "--test.v"}, "v")
+
+		if !reflect.DeepEqual(args, []string{"-test.run=X"}) {
+			t.Errorf("Expected only -test.run, got %v", args)
+		}
+	})
+}
+
+func TestEcho(t *testing.T) {
+	output := []string{
+		"\x16=== RUN   TestAdd",
+		"    add_test.go:5: passing log",
+		"\x16--- PASS: TestAdd (0.00s)",
+		"\x16=== RUN   TestDivide",
+		"\x16=== RUN   TestDivide/by_zero",
+		"\x0f    divide_test.go:7: division by zero\x0e",
+		"\x16--- FAIL: TestDivide/by_zero (0.00s)",
+		"\x16=== NAME  TestDivide",
+		"    divide_test.go:9: parent log",
+		"\x16--- FAIL: TestDivide (0.00s)",
+		"\x16FAIL",
+	}
+
+	t.Run("shows only failed tests when quiet", func(t *testing.T) {
+		expected := strings.Join([]string{
+			"--- FAIL: TestDivide (0.00s)",
+			"    --- FAIL: TestDivide/by_zero (0.00s)",
+			"        divide_test.go:7: division by zero",
+			"    divide_test.go:9: parent log",
+			"FAIL",
+		}, "\n") + "\n"
+
+		if got := echoLines(modeQuiet, output); got != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("keeps the output unchanged when verbose", func(t *testing.T) {
+		expected := strings.Join(output, "\n") + "\n"
+
+		for _, mode := range []string{modeVerbose, modeJSON} {
+			if got := echoLines(mode, output); got != expected {
+				t.Errorf("Expected unchanged output for %s, got %q", mode, got)
+			}
+		}
+	})
+}
+
+func TestHelperPass(t *testing.T) {
+	if os.Getenv(helperEnv) == "" {
+		t.Skip("runs as a helper of TestRun")
+	}
+}
+
+func TestHelperFail(t *testing.T) {
+	if os.Getenv(helperEnv) == "" {
+		t.Skip("runs as a helper of TestRun")
+	}
+	t.Error("helper failed")
+}
+
+// Helper functions
+
+func helperCommand(run string, args ...string) *exec.Cmd {
+	if run != "" {
+		args = append(args, "-test.run="+run)
+	}
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), helperEnv+"=1")
+	return cmd
+}
+
+func newReporter(t *testing.T) *reporter.Reporter {
+	t.Helper()
+	r := reporter.NewReporter(t.TempDir())
+	r.SetPackage("example.com/helper")
+	return r
+}
+
+func readSaved(t *testing.T, r *reporter.Reporter) *transformer.TestResult {
+	t.Helper()
+	data, err := os.ReadFile(r.ResultsPath())
+	if err != nil {
+		t.Fatalf("Expected saved results: %v", err)
+	}
+	var result *transformer.TestResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Invalid saved results: %v", err)
+	}
+	return result
+}
+
+func echoLines(mode string, lines []string) string {
+	var out bytes.Buffer
+	e := newEcho(&out, mode)
+	for _, line := range lines {
+		e.line(line)
+	}
+	return out.String()
+}
@@ -1,6 +1,10 @@
 package transformer
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
 )
 
@@ -15,28 +19,84 @@ type Test struct {
 	FullName string      `json:"fullName"`
 	State    string      `json:"state"`
 	Errors   []TestError `json:"errors,omitempty"`
+	RunID    string      `json:"runId,omitempty"`
+	File     string      `json:"file,omitempty"`
+	Line     int         `json:"line,omitempty"`
+	Slow     bool        `json:"slow,omitempty"`
+
+	// Duration, WallTime, Parallel and Output are kept for other output
+	// formats, not test.json. Duration is the time the test was running;
+	// WallTime also covers the time a parallel test spent paused.
+	Duration time.Duration `json:"-"`
+	WallTime time.Duration `json:"-"`
+	Parallel bool          `json:"-"`
+	Output   string        `json:"-"`
 }
 
 // TestModule represents a module with its tests
 type TestModule struct {
 	ModuleID string `json:"moduleId"`
 	Tests    []Test `json:"tests"`
+	Cached   bool   `json:"cached,omitempty"`
+
+	// Package and Duration are kept for other output formats, not test.json
+	Package  string        `json:"-"`
+	Duration time.Duration `json:"-"`
 }
 
 // TestResult represents the TDD Guard test result format
 type TestResult struct {
 	TestModules []TestModule `json:"testModules"`
 	Reason      string       `json:"reason,omitempty"`
+	Transitions *Transitions `json:"transitions,omitempty"`
+	Runs        []Run        `json:"runs,omitempty"`
+	NotRun      []string     `json:"notRun,omitempty"`
+	Warnings    []string     `json:"warnings,omitempty"`
+}
+
+// Run identifies a single reporter invocation that contributed merged results
+type Run struct {
+	ID        string    `json:"id"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// NewRun creates a run identified by its start time
+func NewRun(startedAt time.Time) Run {
+	startedAt = startedAt.UTC()
+	return Run{
+		ID:        startedAt.Format("20060102T150405.000000000Z"),
+		StartedAt: startedAt,
+	}
+}
+
+// Locator maps packages and tests to source paths relative to the project root
+type Locator interface {
+	PackageDir(pkg string) (string, bool)
+	TestLocation(pkg, test string) (file string, line int, ok bool)
 }
 
 // Transformer transforms parser results to TDD Guard format
-type Transformer struct{}
+type Transformer struct {
+	maxErrorBytes int
+	locator       Locator
+}
 
 // NewTransformer creates a new transformer
 func NewTransformer() *Transformer {
 	return &Transformer{}
 }
 
+// SetMaxErrorBytes limits the size of each error message, 0 keeps them whole
+func (t *Transformer) SetMaxErrorBytes(limit int) {
+	t.maxErrorBytes = limit
+}
+
+// SetLocator reports package directories as module IDs and adds the file
+// and line declaring each test. Packages the locator cannot find keep their import path.
+func (t *Transformer) SetLocator(locator Locator) {
+	t.locator = locator
+}
+
 // Transform converts parser results to TDD Guard format
 func (t *Transformer) Transform(results parser.Results, p *parser.Parser, compilationError *parser.CompilationError) *TestResult {
 	modules := []TestModule{}
@@ -46,7 +106,12 @@ func (t *Transformer) Transform(results parser.Results, p *parser.Parser, compil
 		module := TestModule{
 			ModuleID: pkg,
 			Tests:    transformTests(pkg, tests, p, compilationError),
+			Cached:   p.IsCached(pkg),
+			Package:  pkg,
+			Duration: seconds(p.GetPackageElapsed(pkg)),
 		}
+		t.truncateErrors(module.Tests)
+		t.locate(pkg, &module)
 		modules = append(modules, module)
 
 		// Update reason if any test failed
@@ -72,6 +137,14 @@ func transformTests(pkg string, tests parser.PackageResults, p *parser.Parser, c
 			Name:     name,
 			FullName: pkg + "/" + name,
 			State:    string(state),
+			Duration: seconds(p.GetElapsed(pkg, name)),
+			Output:   p.GetTestOutput(pkg, name),
+		}
+
+		if timing, ok := p.GetTiming(pkg, name); ok {
+			test.Duration = timing.Active
+			test.WallTime = timing.Wall
+			test.Parallel = timing.Parallel
 		}
 
 		// Add error messages for failed tests
@@ -85,10 +158,15 @@ func transformTests(pkg string, tests parser.PackageResults, p *parser.Parser, c
 	return result
 }
 
+// seconds converts elapsed seconds reported by go test to a duration
+func seconds(elapsed float64) time.Duration {
+	return time.Duration(elapsed * float64(time.Second))
+}
+
 // getTestErrors gets the error messages for a failed test
 func getTestErrors(pkg, name string, p *parser.Parser, compilationError *parser.CompilationError) []TestError {
 	// Special case: synthetic CompilationError test
-	if name == "CompilationError" && compilationError != nil {
+	if name == parser.CompilationErrorTest && compilationError != nil {
 		errors := make([]TestError, 0, len(compilationError.Messages))
 		for _, msg := range compilationError.Messages {
 			errors = append(errors, TestError{Message: msg})
@@ -101,3 +179,50 @@ func getTestErrors(pkg, name string, p *parser.Parser, compilationError *parser.
 	}
 	return nil
 }
+
+// ModuleID returns the module ID reported for the package: its directory
+// when the locator finds it, otherwise the import path. A package in the
+// project root directory also keeps its import path, since "." names no file.
+func (t *Transformer) ModuleID(pkg string) string {
+	if t.locator != nil {
+		if dir, ok := t.locator.PackageDir(pkg); ok && dir != "." {
+			return dir
+		}
+	}
+	return pkg
+}
+
+// locate replaces the module ID with the package directory and sets test locations
+func (t *Transformer) locate(pkg string, module *TestModule) {
+	if t.locator == nil {
+		return
+	}
+	module.ModuleID = t.ModuleID(pkg)
+	for i := range module.Tests {
+		if file, line, ok := t.locator.TestLocation(pkg, module.Tests[i].Name); ok {
+			module.Tests[i].File = file
+			module.Tests[i].Line = line
+		}
+	}
+}
+
+// truncateErrors shortens error messages exceeding the configured limit
+func (t *Transformer) truncateErrors(tests []Test) {
+	if t.maxErrorBytes <= 0 {
+		return
+	}
+	for i := range tests {
+		for j := range tests[i].Errors {
+			tests[i].Errors[j].Message = truncate(tests[i].Errors[j].Message, t.maxErrorBytes)
+		}
+	}
+}
+
+// truncate cuts a message to limit bytes without splitting a UTF-8 sequence
+func truncate(message string, limit int) string {
+	if len(message) <= limit {
+		return message
+	}
+	cut := strings.ToValidUTF8(message[:limit], "")
+	return fmt.Sprintf("%s... [truncated %d bytes]", cut, len(message)-len(cut))
+}
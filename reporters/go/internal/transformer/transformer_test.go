@@ -1,8 +1,11 @@
 package transformer
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
 )
@@ -155,6 +158,153 @@ func TestTransformer(t *testing.T) {
 			})
 		})
 
+		t.Run("Error size limit", func(t *testing.T) {
+			p := parser.NewParser()
+			input := strings.Join([]string{
+				`{"Action":"output","Package":"example.com/pkg","Test":"TestFail","Output":"0123456789abcdef\n"}`,
+				`{"Action":"fail","Package":"example.com/pkg","Test":"TestFail"}`,
+			}, "\n")
+			p.Parse(strings.NewReader(input))
+
+			t.Run("keeps messages whole by default", func(t *testing.T) {
+				output := NewTransformer().Transform(p.GetResults(), p, nil)
+				if got := getFirstTest(t, output).Errors[0].Message; got != "0123456789abcdef" {
+					t.Errorf("Expected full message, got %q", got)
+				}
+			})
+
+			t.Run("truncates messages exceeding the limit", func(t *testing.T) {
+				transformer := NewTransformer()
+				transformer.SetMaxErrorBytes(4)
+				output := transformer.Transform(p.GetResults(), p, nil)
+
+				expected := "0123... [truncated 12 bytes]"
+				if got := getFirstTest(t, output).Errors[0].Message; got != expected {
+					t.Errorf("Expected %q, got %q", expected, got)
+				}
+			})
+
+			t.Run("does not split multi-byte characters", func(t *testing.T) {
+				if got := truncate("héllo", 2); got != "h... [truncated 5 bytes]" {
+					t.Errorf("Expected cut before multi-byte character, got %q", got)
+				}
+			})
+		})
+
+		t.Run("Source locations", func(t *testing.T) {
+			results := parser.Results{
+				"example.com/pkg":   {"TestFound": parser.StatePassed, "TestMissing": parser.StatePassed},
+				"example.com/other": {"TestOther": parser.StatePassed},
+				"example.com":       {"TestRoot": parser.StatePassed},
+			}
+			transformer := NewTransformer()
+			transformer.SetLocator(fakeLocator{
+				dirs:  map[string]string{"example.com/pkg": "pkg", "example.com": "."},
+				tests: map[string]fakeLocation{"TestFound": {"pkg/pkg_test.go", 12}},
+			})
+			output := transformer.Transform(results, parser.NewParser(), nil)
+
+			t.Run("uses package directory as moduleId", func(t *testing.T) {
+				if module := findModule(output, "pkg"); module == nil {
+					t.Errorf("Expected module with package directory, got %+v", output.TestModules)
+				}
+			})
+
+			t.Run("keeps import path for unknown packages", func(t *testing.T) {
+				if module := findModule(output, "example.com/other"); module == nil {
+					t.Errorf("Expected module with import path, got %+v", output.TestModules)
+				}
+			})
+
+			t.Run("keeps import path for the package in the project root", func(t *testing.T) {
+				if module := findModule(output, "example.com"); module == nil {
+					t.Errorf("Expected module with import path, got %+v", output.TestModules)
+				}
+			})
+
+			t.Run("sets file and line declaring the test", func(t *testing.T) {
+				for _, test := range findModule(output, "pkg").Tests {
+					expected := map[string]fakeLocation{"TestFound": {"pkg/pkg_test.go", 12}}[test.Name]
+					if test.File != expected.file || test.Line != expected.line {
+						t.Errorf("Expected %s at %s:%d, got %s:%d", test.Name, expected.file, expected.line, test.File, test.Line)
+					}
+				}
+			})
+
+			t.Run("keeps fullName based on import path", func(t *testing.T) {
+				test := findModule(output, "pkg").Tests[0]
+				if !strings.HasPrefix(test.FullName, "example.com/pkg/") {
+					t.Errorf("Expected fullName with import path, got %q", test.FullName)
+				}
+			})
+		})
+
+		t.Run("Timing and output", func(t *testing.T) {
+			p := parser.NewParser()
+			p.Parse(strings.NewReader(strings.Join([]string{
+				`{"Action":"output","Package":"example.com/pkg","Test":"TestLog","Output":"    log_test.go:5: hello\n"}`,
+				`{"Action":"pass","Package":"example.com/pkg","Test":"TestLog","Elapsed":0.25}`,
+				`{"Action":"pass","Package":"example.com/pkg","Elapsed":0.5}`,
+			}, "\n")))
+			output := NewTransformer().Transform(p.GetResults(), p, nil)
+			module := findModule(output, "example.com/pkg")
+
+			t.Run("sets test duration", func(t *testing.T) {
+				if module.Tests[0].Duration != 250*time.Millisecond {
+					t.Errorf("Expected 250ms, got %v", module.Tests[0].Duration)
+				}
+			})
+
+			t.Run("sets package and its duration", func(t *testing.T) {
+				if module.Package != "example.com/pkg" || module.Duration != 500*time.Millisecond {
+					t.Errorf("Expected example.com/pkg taking 500ms, got %s taking %v", module.Package, module.Duration)
+				}
+			})
+
+			t.Run("keeps test output", func(t *testing.T) {
+				if module.Tests[0].Output != "log_test.go:5: hello" {
+					t.Errorf("Expected test output, got %q", module.Tests[0].Output)
+				}
+			})
+
+			t.Run("uses active time of parallel tests", func(t *testing.T) {
+				timed := parser.NewParser()
+				timed.Parse(strings.NewReader(strings.Join([]string{
+					`{"Time":"2025-01-01T10:00:00Z","Action":"run","Package":"example.com/pkg","Test":"TestA"}`,
+					`{"Time":"2025-01-01T10:00:00Z","Action":"pause","Package":"example.com/pkg","Test":"TestA"}`,
+					`{"Time":"2025-01-01T10:00:02Z","Action":"cont","Package":"example.com/pkg","Test":"TestA"}`,
+					`{"Time":"2025-01-01T10:00:03Z","Action":"pass","Package":"example.com/pkg","Test":"TestA","Elapsed":1}`,
+				}, "\n")))
+				test := NewTransformer().Transform(timed.GetResults(), timed, nil).TestModules[0].Tests[0]
+
+				if test.Duration != time.Second || test.WallTime != 3*time.Second || !test.Parallel {
+					t.Errorf("Expected 1s active of 3s wall in parallel, got %v of %v (parallel %v)", test.Duration, test.WallTime, test.Parallel)
+				}
+			})
+
+			t.Run("leaves them out of test.json", func(t *testing.T) {
+				data, _ := json.Marshal(output)
+				if bytes.Contains(data, []byte("hello")) || bytes.Contains(data, []byte("250")) {
+					t.Errorf("Expected no timing or output in JSON, got %s", data)
+				}
+			})
+		})
+
+		t.Run("Cached packages", func(t *testing.T) {
+			p := parser.NewParser()
+			p.Parse(strings.NewReader(strings.Join([]string{
+				`{"Action":"pass","Package":"example.com/pkg","Test":"TestCached"}`,
+				`{"Action":"output","Package":"example.com/pkg","Output":"ok  \texample.com/pkg\t(cached)\n"}`,
+				`{"Action":"pass","Package":"example.com/pkg"}`,
+			}, "\n")))
+			output := NewTransformer().Transform(p.GetResults(), p, nil)
+
+			data, _ := json.Marshal(output)
+			if !bytes.Contains(data, []byte(`"cached":true`)) {
+				t.Errorf("Expected cached module in JSON, got %s", data)
+			}
+		})
+
 		t.Run("Result reason", func(t *testing.T) {
 			t.Run("is always set", func(t *testing.T) {
 				results := createSingleTest(testName, parser.StatePassed)
@@ -255,6 +405,35 @@ func transformAndGetFirstTest(t *testing.T, state parser.TestState) Test {
 	return getFirstTest(t, output)
 }
 
+type fakeLocation struct {
+	file string
+	line int
+}
+
+type fakeLocator struct {
+	dirs  map[string]string
+	tests map[string]fakeLocation
+}
+
+func (l fakeLocator) PackageDir(pkg string) (string, bool) {
+	dir, ok := l.dirs[pkg]
+	return dir, ok
+}
+
+func (l fakeLocator) TestLocation(pkg, test string) (string, int, bool) {
+	location, ok := l.tests[test]
+	return location.file, location.line, ok
+}
+
+func findModule(output *TestResult, moduleID string) *TestModule {
+	for i := range output.TestModules {
+		if output.TestModules[i].ModuleID == moduleID {
+			return &output.TestModules[i]
+		}
+	}
+	return nil
+}
+
 func TestTransformer_CompilationErrorWithMultipleMessages(t *testing.T) {
 	// Test that multiple compilation error messages are transformed correctly
 	results := parser.Results{
@@ -0,0 +1,104 @@
+package transformer
+
+import "sort"
+
+// Transitions describes how test states changed between two consecutive runs.
+// Tests are identified by their full name.
+type Transitions struct {
+	NewlyFailed  []string `json:"newlyFailed,omitempty"`
+	NewlyPassed  []string `json:"newlyPassed,omitempty"`
+	NewlySkipped []string `json:"newlySkipped,omitempty"`
+	Unskipped    []string `json:"unskipped,omitempty"`
+	Added        []string `json:"added,omitempty"`
+	Removed      []string `json:"removed,omitempty"`
+}
+
+// IsEmpty reports whether no test changed between the runs
+func (tr *Transitions) IsEmpty() bool {
+	return tr == nil ||
+		len(tr.NewlyFailed) == 0 &&
+			len(tr.NewlyPassed) == 0 &&
+			len(tr.NewlySkipped) == 0 &&
+			len(tr.Unskipped) == 0 &&
+			len(tr.Added) == 0 &&
+			len(tr.Removed) == 0
+}
+
+// CompareResults computes the transitions from previous to current.
+// Returns nil when there is no previous result to compare against.
+// Tests are only reported as removed when their module is part of the
+// current result, since a module missing entirely may simply not have run.
+func CompareResults(previous, current *TestResult) *Transitions {
+	if previous == nil || current == nil {
+		return nil
+	}
+
+	before := indexStates(previous)
+	transitions := &Transitions{}
+	currentModules := make(map[string]bool)
+
+	for _, module := range current.TestModules {
+		currentModules[module.ModuleID] = true
+
+		for _, test := range module.Tests {
+			oldState, existed := before[test.FullName]
+			if !existed {
+				transitions.Added = append(transitions.Added, test.FullName)
+				continue
+			}
+			recordTransition(transitions, test.FullName, oldState, test.State)
+		}
+	}
+
+	after := indexStates(current)
+	for _, module := range previous.TestModules {
+		if !currentModules[module.ModuleID] {
+			continue
+		}
+		for _, test := range module.Tests {
+			if _, exists := after[test.FullName]; !exists {
+				transitions.Removed = append(transitions.Removed, test.FullName)
+			}
+		}
+	}
+
+	transitions.sort()
+	return transitions
+}
+
+// recordTransition classifies a state change of an existing test
+func recordTransition(transitions *Transitions, name, oldState, newState string) {
+	switch {
+	case oldState == newState:
+		return
+	case newState == "failed":
+		transitions.NewlyFailed = append(transitions.NewlyFailed, name)
+	case newState == "skipped":
+		transitions.NewlySkipped = append(transitions.NewlySkipped, name)
+	case oldState == "failed":
+		transitions.NewlyPassed = append(transitions.NewlyPassed, name)
+	case oldState == "skipped":
+		transitions.Unskipped = append(transitions.Unskipped, name)
+	}
+}
+
+// indexStates maps each test's full name to its state
+func indexStates(result *TestResult) map[string]string {
+	states := make(map[string]string)
+	for _, module := range result.TestModules {
+		for _, test := range module.Tests {
+			states[test.FullName] = test.State
+		}
+	}
+	return states
+}
+
+// sort orders every list for stable output
+func (tr *Transitions) sort() {
+	for _, list := range [][]string{
+		tr.NewlyFailed, tr.NewlyPassed, tr.NewlySkipped,
+		tr.Unskipped, tr.Added, tr.Removed,
+	} {
+		sort.Strings(list)
+	}
+}
@@ -0,0 +1,106 @@
+package transformer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareResults(t *testing.T) {
+	t.Run("returns nil without previous result", func(t *testing.T) {
+		current := createResult(map[string]string{"TestA": "passed"})
+		if transitions := CompareResults(nil, current); transitions != nil {
+			t.Fatalf("Expected nil transitions, got %+v", transitions)
+		}
+	})
+
+	t.Run("is empty when nothing changed", func(t *testing.T) {
+		previous := createResult(map[string]string{"TestA": "passed"})
+		current := createResult(map[string]string{"TestA": "passed"})
+
+		transitions := CompareResults(previous, current)
+		if !transitions.IsEmpty() {
+			t.Fatalf("Expected no transitions, got %+v", transitions)
+		}
+	})
+
+	t.Run("State changes", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			from     string
+			to       string
+			expected func(*Transitions) []string
+		}{
+			{"newly failed", "passed", "failed", func(tr *Transitions) []string { return tr.NewlyFailed }},
+			{"newly failed from skipped", "skipped", "failed", func(tr *Transitions) []string { return tr.NewlyFailed }},
+			{"newly passed", "failed", "passed", func(tr *Transitions) []string { return tr.NewlyPassed }},
+			{"newly skipped", "passed", "skipped", func(tr *Transitions) []string { return tr.NewlySkipped }},
+			{"unskipped", "skipped", "passed", func(tr *Transitions) []string { return tr.Unskipped }},
+		}
+
+		for _, tc := range testCases {
+			t.Run("reports "+tc.name, func(t *testing.T) {
+				previous := createResult(map[string]string{"TestA": tc.from})
+				current := createResult(map[string]string{"TestA": tc.to})
+
+				transitions := CompareResults(previous, current)
+				expected := []string{testPackage + "/TestA"}
+				if got := tc.expected(transitions); !reflect.DeepEqual(got, expected) {
+					t.Errorf("Expected %v, got %v", expected, got)
+				}
+			})
+		}
+	})
+
+	t.Run("reports added tests", func(t *testing.T) {
+		previous := createResult(map[string]string{"TestA": "passed"})
+		current := createResult(map[string]string{"TestA": "passed", "TestB": "passed"})
+
+		transitions := CompareResults(previous, current)
+		expected := []string{testPackage + "/TestB"}
+		if !reflect.DeepEqual(transitions.Added, expected) {
+			t.Errorf("Expected added %v, got %v", expected, transitions.Added)
+		}
+	})
+
+	t.Run("reports removed tests of modules that ran", func(t *testing.T) {
+		previous := createResult(map[string]string{"TestA": "passed", "TestB": "passed"})
+		current := createResult(map[string]string{"TestA": "passed"})
+
+		transitions := CompareResults(previous, current)
+		expected := []string{testPackage + "/TestB"}
+		if !reflect.DeepEqual(transitions.Removed, expected) {
+			t.Errorf("Expected removed %v, got %v", expected, transitions.Removed)
+		}
+	})
+
+	t.Run("does not report tests of modules that did not run as removed", func(t *testing.T) {
+		previous := createResult(map[string]string{"TestA": "passed"})
+		current := &TestResult{TestModules: []TestModule{}}
+
+		transitions := CompareResults(previous, current)
+		if !transitions.IsEmpty() {
+			t.Fatalf("Expected no transitions, got %+v", transitions)
+		}
+	})
+
+	t.Run("sorts test names", func(t *testing.T) {
+		previous := createResult(map[string]string{})
+		current := createResult(map[string]string{"TestC": "passed", "TestA": "passed", "TestB": "passed"})
+
+		transitions := CompareResults(previous, current)
+		expected := []string{testPackage + "/TestA", testPackage + "/TestB", testPackage + "/TestC"}
+		if !reflect.DeepEqual(transitions.Added, expected) {
+			t.Errorf("Expected sorted %v, got %v", expected, transitions.Added)
+		}
+	})
+}
+
+func createResult(states map[string]string) *TestResult {
+	tests := []Test{}
+	for name, state := range states {
+		tests = append(tests, Test{Name: name, FullName: testPackage + "/" + name, State: state})
+	}
+	return &TestResult{
+		TestModules: []TestModule{{ModuleID: testPackage, Tests: tests}},
+	}
+}
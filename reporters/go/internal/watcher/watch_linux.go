@@ -0,0 +1,123 @@
+//go:build linux
+
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// inotify watches every directory of a tree
+type inotify struct {
+	file *os.File
+	root string
+	dirs map[int32]string
+}
+
+// watch uses inotify, falling back to polling when it is unavailable
+func watch(ctx context.Context, root string, changes chan<- string) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return poll(ctx, root, changes)
+	}
+
+	w := &inotify{file: os.NewFile(uintptr(fd), "inotify"), root: root, dirs: make(map[int32]string)}
+	defer w.file.Close()
+
+	if err := w.addTree(root, nil); err != nil {
+		return err
+	}
+
+	// Closing the file unblocks a pending read
+	go func() {
+		<-ctx.Done()
+		w.file.Close()
+	}()
+
+	buffer := make([]byte, 64*1024)
+	for {
+		n, err := w.file.Read(buffer)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read inotify events: %w", err)
+		}
+
+		var found []string
+		w.parse(buffer[:n], &found)
+		for _, path := range found {
+			if !send(ctx, changes, path) {
+				return nil
+			}
+		}
+	}
+}
+
+// parse decodes inotify events, watching new directories as they appear
+func (w *inotify) parse(buffer []byte, found *[]string) {
+	for offset := 0; offset+syscall.SizeofInotifyEvent <= len(buffer); {
+		event := (*syscall.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+		nameStart := offset + syscall.SizeofInotifyEvent
+		offset = nameStart + int(event.Len)
+
+		dir, exists := w.dirs[event.Wd]
+		if !exists || event.Len == 0 {
+			continue
+		}
+		name := string(buffer[nameStart:offset])
+		for len(name) > 0 && name[len(name)-1] == 0 {
+			name = name[:len(name)-1]
+		}
+		path := filepath.Join(dir, name)
+
+		if event.Mask&syscall.IN_ISDIR != 0 {
+			if event.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 && !skipDir(w.root, path, name) {
+				// Files may have been written before the watch was added
+				w.addTree(path, found)
+			}
+			continue
+		}
+		if IsRelevant(path) {
+			*found = append(*found, path)
+		}
+	}
+}
+
+// addTree watches dir and its subdirectories. When found is set, relevant
+// files already present are reported as changed.
+func (w *inotify) addTree(dir string, found *[]string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			if found != nil && IsRelevant(path) {
+				*found = append(*found, path)
+			}
+			return nil
+		}
+		if skipDir(w.root, path, d.Name()) {
+			return filepath.SkipDir
+		}
+
+		wd, err := syscall.InotifyAddWatch(int(w.file.Fd()), path, inotifyMask)
+		if err != nil {
+			if errors.Is(err, syscall.ENOENT) {
+				return nil
+			}
+			return fmt.Errorf("watch %s: %w", path, err)
+		}
+		w.dirs[int32(wd)] = path
+		return nil
+	})
+}
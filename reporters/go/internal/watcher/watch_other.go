@@ -0,0 +1,10 @@
+//go:build !linux
+
+package watcher
+
+import "context"
+
+// watch polls for changes on platforms without inotify
+func watch(ctx context.Context, root string, changes chan<- string) error {
+	return poll(ctx, root, changes)
+}
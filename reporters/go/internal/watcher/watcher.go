@@ -0,0 +1,162 @@
+package watcher
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PollInterval is how often the polling watcher scans for changes
+var PollInterval = 500 * time.Millisecond
+
+// Watch reports changes to Go source, go.mod, go.sum and testdata files
+// below root on changes until ctx is done. It uses inotify where available
+// and falls back to polling.
+func Watch(ctx context.Context, root string, changes chan<- string) error {
+	return watch(ctx, root, changes)
+}
+
+// IsRelevant checks if a change to the file can affect test results
+func IsRelevant(path string) bool {
+	switch filepath.Base(path) {
+	case "go.mod", "go.sum":
+		return true
+	}
+	if filepath.Ext(path) == ".go" {
+		return true
+	}
+	return inTestdata(path)
+}
+
+// Debounce groups changes arriving within quiet of each other into batches
+// of unique paths, sent once no change arrived for quiet
+func Debounce(ctx context.Context, changes <-chan string, quiet time.Duration) <-chan []string {
+	batches := make(chan []string)
+
+	go func() {
+		defer close(batches)
+		var pending []string
+		seen := make(map[string]bool)
+		timer := time.NewTimer(quiet)
+		timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case path, ok := <-changes:
+				if !ok {
+					return
+				}
+				if !seen[path] {
+					seen[path] = true
+					pending = append(pending, path)
+				}
+				timer.Reset(quiet)
+			case <-timer.C:
+				select {
+				case batches <- pending:
+				case <-ctx.Done():
+					return
+				}
+				pending = nil
+				seen = make(map[string]bool)
+			}
+		}
+	}()
+	return batches
+}
+
+// skipDir checks if a directory is ignored, like the go tool ignores it
+func skipDir(root, path, name string) bool {
+	if path == root {
+		return false
+	}
+	return name == "vendor" || name == "node_modules" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// inTestdata checks if the path is inside a testdata directory
+func inTestdata(path string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if part == "testdata" {
+			return true
+		}
+	}
+	return false
+}
+
+// fileState identifies a version of a file for polling
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// poll scans root every PollInterval and reports relevant files that were
+// added, modified or removed
+func poll(ctx context.Context, root string, changes chan<- string) error {
+	previous := scan(root)
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current := scan(root)
+		for path, state := range current {
+			if old, exists := previous[path]; !exists || old != state {
+				if !send(ctx, changes, path) {
+					return nil
+				}
+			}
+		}
+		for path := range previous {
+			if _, exists := current[path]; !exists {
+				if !send(ctx, changes, path) {
+					return nil
+				}
+			}
+		}
+		previous = current
+	}
+}
+
+// scan records the state of every relevant file below root
+func scan(root string) map[string]fileState {
+	files := make(map[string]fileState)
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if skipDir(root, path, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !IsRelevant(path) {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	return files
+}
+
+// send delivers a change unless ctx is done first
+func send(ctx context.Context, changes chan<- string, path string) bool {
+	select {
+	case changes <- path:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,193 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsRelevant(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"/src/app/app.go", true},
+		{"/src/app/app_test.go", true},
+		{"/src/app/go.mod", true},
+		{"/src/app/go.sum", true},
+		{"/src/app/testdata/input.json", true},
+		{"/src/app/README.md", false},
+		{"/src/app/app.go.swp", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := IsRelevant(tt.path); got != tt.expected {
+				t.Errorf("Expected IsRelevant(%q) to be %v, got %v", tt.path, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	t.Run("batches unique changes arriving together", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changes := make(chan string)
+		batches := Debounce(ctx, changes, 50*time.Millisecond)
+
+		for _, path := range []string{"a.go", "b.go", "a.go"} {
+			changes <- path
+		}
+
+		assertBatch(t, batches, []string{"a.go", "b.go"})
+	})
+
+	t.Run("starts a new batch after a quiet period", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changes := make(chan string)
+		batches := Debounce(ctx, changes, 20*time.Millisecond)
+
+		changes <- "a.go"
+		assertBatch(t, batches, []string{"a.go"})
+		changes <- "a.go"
+		assertBatch(t, batches, []string{"a.go"})
+	})
+
+	t.Run("closes batches when changes close", func(t *testing.T) {
+		changes := make(chan string)
+		batches := Debounce(context.Background(), changes, time.Second)
+
+		close(changes)
+
+		if _, ok := <-batches; ok {
+			t.Error("Expected batches to be closed")
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("reports changes to watched files", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "app.go", "package app\n")
+		changes := startWatch(t, watch, root)
+
+		writeFile(t, root, "app.go", "package app\n\n// changed\n")
+
+		assertChange(t, changes, filepath.Join(root, "app.go"))
+	})
+
+	t.Run("reports files in new directories", func(t *testing.T) {
+		root := t.TempDir()
+		changes := startWatch(t, watch, root)
+
+		writeFile(t, root, "sub/testdata/input.txt", "input")
+
+		assertChange(t, changes, filepath.Join(root, "sub", "testdata", "input.txt"))
+	})
+
+	t.Run("ignores hidden and vendor directories", func(t *testing.T) {
+		root := t.TempDir()
+		changes := startWatch(t, watch, root)
+
+		writeFile(t, root, ".git/hooks/hook.go", "package hooks\n")
+		writeFile(t, root, "vendor/dep/dep.go", "package dep\n")
+		writeFile(t, root, "app.go", "package app\n")
+
+		assertChange(t, changes, filepath.Join(root, "app.go"))
+	})
+}
+
+func TestPoll(t *testing.T) {
+	PollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { PollInterval = 500 * time.Millisecond })
+
+	t.Run("reports added files", func(t *testing.T) {
+		root := t.TempDir()
+		changes := startWatch(t, poll, root)
+
+		writeFile(t, root, "app.go", "package app\n")
+
+		assertChange(t, changes, filepath.Join(root, "app.go"))
+	})
+
+	t.Run("reports removed files", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n")
+		changes := startWatch(t, poll, root)
+
+		os.Remove(filepath.Join(root, "go.mod"))
+
+		assertChange(t, changes, filepath.Join(root, "go.mod"))
+	})
+
+	t.Run("ignores irrelevant files", func(t *testing.T) {
+		root := t.TempDir()
+		changes := startWatch(t, poll, root)
+
+		writeFile(t, root, "notes.md", "notes")
+		writeFile(t, root, "app.go", "package app\n")
+
+		assertChange(t, changes, filepath.Join(root, "app.go"))
+	})
+}
+
+// Helper functions
+
+func startWatch(t *testing.T, watchFunc func(context.Context, string, chan<- string) error, root string) <-chan string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan string, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := watchFunc(ctx, root, changes); err != nil {
+			t.Errorf("watch failed: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	// Give the watcher time to take its initial snapshot
+	time.Sleep(50 * time.Millisecond)
+	return changes
+}
+
+func assertChange(t *testing.T, changes <-chan string, expected string) {
+	t.Helper()
+	select {
+	case path := <-changes:
+		if path != expected {
+			t.Errorf("Expected change to %s, got %s", expected, path)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for change to %s", expected)
+	}
+}
+
+func assertBatch(t *testing.T, batches <-chan []string, expected []string) {
+	t.Helper()
+	select {
+	case batch := <-batches:
+		if !reflect.DeepEqual(batch, expected) {
+			t.Errorf("Expected batch %v, got %v", expected, batch)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for batch %v", expected)
+	}
+}
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	os.MkdirAll(filepath.Dir(path), 0755)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
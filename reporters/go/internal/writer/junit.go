@@ -0,0 +1,193 @@
+package writer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the tests of one package
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase holds one test
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *junitSkipped `xml:"skipped"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitProblem describes a failure or error with its full output
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junitSkipped records why a test was skipped
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// NewJUnitWriter creates a writer replacing the file at path with JUnit XML
+func NewJUnitWriter(path string) Writer {
+	return &fileWriter{path: path, format: "JUnit", encode: EncodeJUnit}
+}
+
+// EncodeJUnit writes the results as JUnit XML with a test suite per package
+// and a test case per test, both sorted by name
+func EncodeJUnit(w io.Writer, result *transformer.TestResult) error {
+	report := junitTestSuites{}
+	var total time.Duration
+
+	for _, module := range sortedModules(result) {
+		suite := junitTestSuite{Name: packageName(module), Time: junitTime(module.Duration)}
+		for _, test := range sortedTests(module) {
+			suite.TestCases = append(suite.TestCases, junitCase(suite.Name, test))
+			suite.Tests++
+		}
+		for _, testCase := range suite.TestCases {
+			switch {
+			case testCase.Failure != nil:
+				suite.Failures++
+			case testCase.Error != nil:
+				suite.Errors++
+			case testCase.Skipped != nil:
+				suite.Skipped++
+			}
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		total += module.Duration
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = junitTime(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitCase converts a test to a test case. Failed tests carry their error
+// messages, skipped tests the reason they logged, and other tests their logs.
+func junitCase(suite string, test transformer.Test) junitTestCase {
+	testCase := junitTestCase{
+		Name:      test.Name,
+		ClassName: suite,
+		Time:      junitTime(test.Duration),
+		File:      test.File,
+		Line:      test.Line,
+	}
+	logs := testLogs(test.Output)
+
+	switch test.State {
+	case "failed":
+		messages := make([]string, 0, len(test.Errors))
+		for _, e := range test.Errors {
+			messages = append(messages, e.Message)
+		}
+		body := strings.Join(messages, "\n")
+		problem := &junitProblem{Message: firstLine(body), Type: "failure", Body: body}
+		if test.Name == parser.CompilationErrorTest {
+			problem.Type = parser.CompilationErrorTest
+			testCase.Error = problem
+		} else {
+			testCase.Failure = problem
+		}
+	case "skipped":
+		testCase.Skipped = &junitSkipped{Message: logs}
+	default:
+		testCase.SystemOut = logs
+	}
+	return testCase
+}
+
+// testLogs removes the status lines go test adds to a test's output,
+// leaving what the test logged
+func testLogs(output string) string {
+	var logs []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "=== ") || strings.HasPrefix(line, "--- ") {
+			continue
+		}
+		logs = append(logs, line)
+	}
+	return strings.TrimSpace(strings.Join(logs, "\n"))
+}
+
+// packageName returns the import path of a module, falling back to its ID
+func packageName(module transformer.TestModule) string {
+	if module.Package != "" {
+		return module.Package
+	}
+	return module.ModuleID
+}
+
+// sortedModules returns the result's modules sorted by package
+func sortedModules(result *transformer.TestResult) []transformer.TestModule {
+	modules := append([]transformer.TestModule{}, result.TestModules...)
+	sort.SliceStable(modules, func(i, j int) bool {
+		return packageName(modules[i]) < packageName(modules[j])
+	})
+	return modules
+}
+
+// sortedTests returns the module's tests sorted by name
+func sortedTests(module transformer.TestModule) []transformer.Test {
+	tests := append([]transformer.Test{}, module.Tests...)
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].Name < tests[j].Name
+	})
+	return tests
+}
+
+// firstLine returns the first line of a message
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return line
+}
+
+// junitTime formats a duration as seconds
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
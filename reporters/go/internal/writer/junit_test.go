@@ -0,0 +1,138 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestJUnit(t *testing.T) {
+	result := &transformer.TestResult{
+		TestModules: []transformer.TestModule{
+			{
+				ModuleID: "calc",
+				Package:  "example.com/app/calc",
+				Duration: 1500 * time.Millisecond,
+				Tests: []transformer.Test{
+					{
+						Name:     "TestDivide",
+						State:    "failed",
+						Duration: time.Second,
+						File:     "calc/calc_test.go",
+						Line:     12,
+						Errors:   []transformer.TestError{{Message: "calc_test.go:14: expected 2, got 3\nstack line"}},
+					},
+					{
+						Name:     "TestAdd",
+						State:    "passed",
+						Duration: 250 * time.Millisecond,
+						Output:   "=== RUN   TestAdd\ncalc_test.go:8: adding\n--- PASS: TestAdd (0.25s)",
+					},
+					{
+						Name:   "TestSkip",
+						State:  "skipped",
+						Output: "=== RUN   TestSkip\ncalc_test.go:20: needs network\n--- SKIP: TestSkip (0.00s)",
+					},
+				},
+			},
+			{
+				ModuleID: "api",
+				Package:  "example.com/app/api",
+				Tests: []transformer.Test{
+					{
+						Name:   "CompilationError",
+						State:  "failed",
+						Errors: []transformer.TestError{{Message: "api.go:3:1: syntax error"}},
+					},
+				},
+			},
+		},
+	}
+
+	var output bytes.Buffer
+	if err := EncodeJUnit(&output, result); err != nil {
+		t.Fatalf("EncodeJUnit failed: %v", err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Expected valid XML, got %v:\n%s", err, output.String())
+	}
+
+	t.Run("counts tests of every package", func(t *testing.T) {
+		if report.Tests != 4 || report.Failures != 1 || report.Errors != 1 || report.Skipped != 1 {
+			t.Errorf("Expected 4 tests, 1 failure, 1 error and 1 skipped, got %+v", report)
+		}
+		if report.Time != "1.500" {
+			t.Errorf("Expected total time 1.500, got %s", report.Time)
+		}
+	})
+
+	t.Run("creates a suite per package sorted by import path", func(t *testing.T) {
+		if len(report.Suites) != 2 || report.Suites[0].Name != "example.com/app/api" || report.Suites[1].Name != "example.com/app/calc" {
+			t.Fatalf("Expected api and calc suites, got %+v", report.Suites)
+		}
+		if report.Suites[1].Time != "1.500" {
+			t.Errorf("Expected suite time 1.500, got %s", report.Suites[1].Time)
+		}
+	})
+
+	t.Run("creates a case per test with time and location", func(t *testing.T) {
+		cases := report.Suites[1].TestCases
+		if len(cases) != 3 || cases[0].Name != "TestAdd" || cases[1].Name != "TestDivide" {
+			t.Fatalf("Expected sorted test cases, got %+v", cases)
+		}
+		if cases[1].ClassName != "example.com/app/calc" || cases[1].Time != "1.000" ||
+			cases[1].File != "calc/calc_test.go" || cases[1].Line != 12 {
+			t.Errorf("Expected class name, time and location, got %+v", cases[1])
+		}
+	})
+
+	t.Run("adds failure with message and full output", func(t *testing.T) {
+		failure := report.Suites[1].TestCases[1].Failure
+		if failure == nil {
+			t.Fatal("Expected failure element")
+		}
+		if failure.Message != "calc_test.go:14: expected 2, got 3" || !strings.Contains(failure.Body, "stack line") {
+			t.Errorf("Expected first line as message and full output, got %+v", failure)
+		}
+	})
+
+	t.Run("adds skipped reason", func(t *testing.T) {
+		skipped := report.Suites[1].TestCases[2].Skipped
+		if skipped == nil || skipped.Message != "calc_test.go:20: needs network" {
+			t.Errorf("Expected skip reason, got %+v", skipped)
+		}
+	})
+
+	t.Run("adds logs of passing tests as system-out", func(t *testing.T) {
+		if out := report.Suites[1].TestCases[0].SystemOut; out != "calc_test.go:8: adding" {
+			t.Errorf("Expected test logs, got %q", out)
+		}
+	})
+
+	t.Run("reports build failures as errors", func(t *testing.T) {
+		testCase := report.Suites[0].TestCases[0]
+		if testCase.Error == nil || testCase.Failure != nil {
+			t.Errorf("Expected error element for build failure, got %+v", testCase)
+		}
+	})
+
+	t.Run("writes the report to a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+
+		if err := NewJUnitWriter(path).Write(result); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if !bytes.Equal(data, output.Bytes()) {
+			t.Errorf("Expected file to contain the report, got:\n%s", data)
+		}
+	})
+}
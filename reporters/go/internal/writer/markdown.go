@@ -0,0 +1,99 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// NewMarkdownWriter creates a writer appending a Markdown summary to the
+// file at path, like GitHub Actions step summaries expect
+func NewMarkdownWriter(path string) Writer {
+	return &fileWriter{path: path, format: "Markdown", encode: EncodeMarkdown, append: true}
+}
+
+// EncodeMarkdown writes a summary of the results: a table of test counts
+// and the failing tests with their errors in collapsible sections
+func EncodeMarkdown(w io.Writer, result *transformer.TestResult) error {
+	var passed, failed, skipped int
+	var duration time.Duration
+	var failures []transformer.Test
+
+	for _, module := range sortedModules(result) {
+		duration += module.Duration
+		for _, test := range sortedTests(module) {
+			switch test.State {
+			case "passed":
+				passed++
+			case "failed":
+				failed++
+				failures = append(failures, test)
+			case "skipped":
+				skipped++
+			}
+		}
+	}
+
+	var b strings.Builder
+	if failed > 0 {
+		b.WriteString("## ❌ Tests failed\n\n")
+	} else {
+		b.WriteString("## ✅ Tests passed\n\n")
+	}
+	b.WriteString("| Passed | Failed | Skipped | Total | Duration |\n")
+	b.WriteString("| -----: | -----: | ------: | ----: | -------: |\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d | %.2fs |\n",
+		passed, failed, skipped, passed+failed+skipped, duration.Seconds())
+
+	if len(failures) > 0 {
+		b.WriteString("\n### Failing tests\n")
+		for _, test := range failures {
+			writeMarkdownFailure(&b, test)
+		}
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownFailure adds a collapsible section with the test's errors
+func writeMarkdownFailure(b *strings.Builder, test transformer.Test) {
+	summary := fmt.Sprintf("<code>%s</code>", htmlEscape(test.FullName))
+	if test.File != "" {
+		summary += fmt.Sprintf(" (%s:%d)", htmlEscape(test.File), test.Line)
+	}
+	fmt.Fprintf(b, "\n<details>\n<summary>%s</summary>\n\n", summary)
+
+	var messages []string
+	for _, e := range test.Errors {
+		messages = append(messages, e.Message)
+	}
+	if message := strings.Join(messages, "\n"); message != "" {
+		fence := codeFence(message)
+		fmt.Fprintf(b, "%s\n%s\n%s\n\n", fence, message, fence)
+	}
+	b.WriteString("</details>\n")
+}
+
+// codeFence returns a fence longer than any run of backticks in text
+func codeFence(text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// htmlEscape escapes text placed inside HTML tags
+func htmlEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,71 @@
+package writer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestMarkdown(t *testing.T) {
+	t.Run("summarizes counts in a table", func(t *testing.T) {
+		markdown := encodeMarkdown(t, sampleResult())
+
+		if !strings.HasPrefix(markdown, "## ❌ Tests failed\n") {
+			t.Errorf("Expected failed heading, got:\n%s", markdown)
+		}
+		if !strings.Contains(markdown, "| 2 | 1 | 1 | 4 | 1.50s |") {
+			t.Errorf("Expected counts row, got:\n%s", markdown)
+		}
+	})
+
+	t.Run("lists failing tests in collapsible sections", func(t *testing.T) {
+		markdown := encodeMarkdown(t, sampleResult())
+
+		expected := "<details>\n<summary><code>example.com/app/calc/TestDivide/by_zero</code> (calc/calc_test.go:12)</summary>\n\n" +
+			"```\ncalc_test.go:14: expected error\nstack line\n```\n\n</details>"
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected failure details, got:\n%s", markdown)
+		}
+	})
+
+	t.Run("reports passing runs without failures section", func(t *testing.T) {
+		result := &transformer.TestResult{TestModules: []transformer.TestModule{
+			{Package: "example.com/app", Tests: []transformer.Test{{Name: "TestA", State: "passed"}}},
+		}}
+
+		markdown := encodeMarkdown(t, result)
+
+		if !strings.HasPrefix(markdown, "## ✅ Tests passed\n") || strings.Contains(markdown, "Failing tests") {
+			t.Errorf("Expected passing summary, got:\n%s", markdown)
+		}
+	})
+
+	t.Run("fences errors containing backticks", func(t *testing.T) {
+		result := &transformer.TestResult{TestModules: []transformer.TestModule{
+			{Package: "example.com/app", Tests: []transformer.Test{{
+				Name:   "TestA",
+				State:  "failed",
+				Errors: []transformer.TestError{{Message: "got ```code```"}},
+			}}},
+		}}
+
+		markdown := encodeMarkdown(t, result)
+
+		if !strings.Contains(markdown, "````\ngot ```code```\n````") {
+			t.Errorf("Expected longer fence, got:\n%s", markdown)
+		}
+	})
+}
+
+// Helper functions
+
+func encodeMarkdown(t *testing.T, result *transformer.TestResult) string {
+	t.Helper()
+	var output bytes.Buffer
+	if err := EncodeMarkdown(&output, result); err != nil {
+		t.Fatalf("EncodeMarkdown failed: %v", err)
+	}
+	return output.String()
+}
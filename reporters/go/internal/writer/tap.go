@@ -0,0 +1,137 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// tapIndent indents the lines of a TAP subtest
+const tapIndent = "    "
+
+// tapNode is a package, test or subtest in the TAP output. Only leaves
+// carry a test result; parents pass when none of their children failed.
+type tapNode struct {
+	name     string
+	test     *transformer.Test
+	children []*tapNode
+}
+
+// NewTAPWriter creates a writer replacing the file at path with TAP output
+func NewTAPWriter(path string) Writer {
+	return &fileWriter{path: path, format: "TAP", encode: EncodeTAP}
+}
+
+// EncodeTAP writes the results as TAP version 14. Each package is a
+// subtest holding its tests, and Go subtests are nested subtests of their
+// parent test.
+func EncodeTAP(w io.Writer, result *transformer.TestResult) error {
+	var lines []string
+	add := func(indent, line string) {
+		lines = append(lines, indent+line)
+	}
+
+	add("", "TAP version 14")
+	modules := sortedModules(result)
+	for i, module := range modules {
+		writeTAPNode(add, "", i+1, packageTree(module))
+	}
+	add("", fmt.Sprintf("1..%d", len(modules)))
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// packageTree nests the tests of a module by subtest name
+func packageTree(module transformer.TestModule) *tapNode {
+	root := &tapNode{name: packageName(module)}
+	for _, test := range sortedTests(module) {
+		node := root
+		for _, part := range strings.Split(test.Name, "/") {
+			node = node.child(part)
+		}
+		node.test = &test
+	}
+	return root
+}
+
+// child returns the child with the name, adding it when missing
+func (n *tapNode) child(name string) *tapNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &tapNode{name: name}
+	n.children = append(n.children, c)
+	return c
+}
+
+// failed checks if the node or any of its descendants failed
+func (n *tapNode) failed() bool {
+	if n.test != nil && n.test.State == "failed" {
+		return true
+	}
+	for _, c := range n.children {
+		if c.failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTAPNode writes a test point for the node, preceded by its children
+// as an indented subtest
+func writeTAPNode(add func(indent, line string), indent string, number int, n *tapNode) {
+	if len(n.children) > 0 {
+		add(indent, "# Subtest: "+n.name)
+		for i, c := range n.children {
+			writeTAPNode(add, indent+tapIndent, i+1, c)
+		}
+		add(indent+tapIndent, fmt.Sprintf("1..%d", len(n.children)))
+	}
+
+	status := "ok"
+	if n.failed() {
+		status = "not ok"
+	}
+	point := fmt.Sprintf("%s %d - %s", status, number, tapEscape(n.name))
+	if n.test != nil && n.test.State == "skipped" {
+		point += " # SKIP"
+		if reason := firstLine(testLogs(n.test.Output)); reason != "" {
+			point += " " + tapEscape(reason)
+		}
+	}
+	add(indent, point)
+
+	if n.test != nil && n.test.State == "failed" {
+		writeTAPDiagnostics(add, indent+"  ", n.test)
+	}
+}
+
+// writeTAPDiagnostics adds a YAML block with the failure details
+func writeTAPDiagnostics(add func(indent, line string), indent string, test *transformer.Test) {
+	add(indent, "---")
+	var messages []string
+	for _, e := range test.Errors {
+		messages = append(messages, e.Message)
+	}
+	if message := strings.Join(messages, "\n"); message != "" {
+		add(indent, "message: |-")
+		for _, line := range strings.Split(message, "\n") {
+			add(indent, "  "+line)
+		}
+	}
+	if test.File != "" {
+		add(indent, fmt.Sprintf("at: %s:%d", test.File, test.Line))
+	}
+	add(indent, fmt.Sprintf("duration_ms: %d", test.Duration.Milliseconds()))
+	add(indent, "...")
+}
+
+// tapEscape escapes characters with a meaning in test point descriptions
+func tapEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "#", `\#`).Replace(text)
+}
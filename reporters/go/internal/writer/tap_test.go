@@ -0,0 +1,62 @@
+package writer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTAP(t *testing.T) {
+	var output bytes.Buffer
+	if err := EncodeTAP(&output, sampleResult()); err != nil {
+		t.Fatalf("EncodeTAP failed: %v", err)
+	}
+	tap := output.String()
+
+	t.Run("starts with the version and ends with the plan", func(t *testing.T) {
+		if !strings.HasPrefix(tap, "TAP version 14\n") || !strings.HasSuffix(tap, "\n1..2\n") {
+			t.Errorf("Expected version and plan for 2 packages, got:\n%s", tap)
+		}
+	})
+
+	t.Run("nests tests and subtests as subtests", func(t *testing.T) {
+		expected := strings.Join([]string{
+			"# Subtest: example.com/app/calc",
+			"    ok 1 - TestAdd",
+			"    # Subtest: TestDivide",
+			"        not ok 1 - by_zero",
+		}, "\n")
+		if !strings.Contains(tap, expected) {
+			t.Errorf("Expected nested subtests, got:\n%s", tap)
+		}
+	})
+
+	t.Run("fails parents of failing tests", func(t *testing.T) {
+		for _, expected := range []string{"\n    not ok 2 - TestDivide\n", "\nnot ok 1 - example.com/app/calc\n"} {
+			if !strings.Contains(tap, expected) {
+				t.Errorf("Expected %q, got:\n%s", expected, tap)
+			}
+		}
+	})
+
+	t.Run("adds YAML diagnostics to failures", func(t *testing.T) {
+		expected := strings.Join([]string{
+			"          ---",
+			"          message: |-",
+			"            calc_test.go:14: expected error",
+			"            stack line",
+			"          at: calc/calc_test.go:12",
+			"          duration_ms: 1000",
+			"          ...",
+		}, "\n")
+		if !strings.Contains(tap, expected) {
+			t.Errorf("Expected diagnostics, got:\n%s", tap)
+		}
+	})
+
+	t.Run("marks skipped tests with escaped reason", func(t *testing.T) {
+		if !strings.Contains(tap, `    ok 1 - TestDial # SKIP net_test.go:9: needs network \#42`) {
+			t.Errorf("Expected skip directive, got:\n%s", tap)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+// Writer saves test results in an output format besides test.json
+type Writer interface {
+	Write(result *transformer.TestResult) error
+}
+
+// fileWriter writes results encoded in one format to a file
+type fileWriter struct {
+	path   string
+	format string
+	encode func(io.Writer, *transformer.TestResult) error
+	append bool
+}
+
+// Write encodes the results into the file, replacing or appending to it
+func (w *fileWriter) Write(result *transformer.TestResult) error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("create %s directory: %w", w.format, err)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if w.append {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	file, err := os.OpenFile(w.path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("create %s file: %w", w.format, err)
+	}
+	if err := w.encode(file, result); err != nil {
+		file.Close()
+		return fmt.Errorf("write %s file: %w", w.format, err)
+	}
+	return file.Close()
+}
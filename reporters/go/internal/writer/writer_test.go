@@ -0,0 +1,112 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+)
+
+func TestFileWriter(t *testing.T) {
+	result := sampleResult()
+
+	t.Run("replaces existing files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "results.tap")
+		os.WriteFile(path, []byte("previous\n"), 0644)
+
+		if err := NewTAPWriter(path).Write(result); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if !strings.HasPrefix(string(data), "TAP version 14\n") {
+			t.Errorf("Expected file to be replaced, got:\n%s", data)
+		}
+	})
+
+	t.Run("appends when the format is a running log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "summary.md")
+		os.WriteFile(path, []byte("# Build\n"), 0644)
+
+		if err := NewMarkdownWriter(path).Write(result); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if !strings.HasPrefix(string(data), "# Build\n## ") {
+			t.Errorf("Expected summary appended, got:\n%s", data)
+		}
+	})
+
+	t.Run("creates missing directories", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "reports", "ci", "junit.xml")
+
+		if err := NewJUnitWriter(path).Write(result); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected file to be created: %v", err)
+		}
+	})
+
+	t.Run("names the format in errors", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := NewTAPWriter(dir).Write(result)
+
+		if err == nil || !strings.Contains(err.Error(), "TAP") {
+			t.Errorf("Expected TAP error, got %v", err)
+		}
+	})
+}
+
+// Helper functions
+
+func sampleResult() *transformer.TestResult {
+	return &transformer.TestResult{
+		TestModules: []transformer.TestModule{
+			{
+				ModuleID: "calc",
+				Package:  "example.com/app/calc",
+				Duration: 1500 * time.Millisecond,
+				Tests: []transformer.Test{
+					{
+						Name:     "TestDivide/by_zero",
+						FullName: "example.com/app/calc/TestDivide/by_zero",
+						State:    "failed",
+						Duration: time.Second,
+						File:     "calc/calc_test.go",
+						Line:     12,
+						Errors:   []transformer.TestError{{Message: "calc_test.go:14: expected error\nstack line"}},
+					},
+					{
+						Name:     "TestDivide/simple",
+						FullName: "example.com/app/calc/TestDivide/simple",
+						State:    "passed",
+					},
+					{
+						Name:     "TestAdd",
+						FullName: "example.com/app/calc/TestAdd",
+						State:    "passed",
+					},
+				},
+			},
+			{
+				ModuleID: "net",
+				Package:  "example.com/app/net",
+				Tests: []transformer.Test{
+					{
+						Name:     "TestDial",
+						FullName: "example.com/app/net/TestDial",
+						State:    "skipped",
+						Output:   "=== RUN   TestDial\nnet_test.go:9: needs network #42\n--- SKIP: TestDial (0.00s)",
+					},
+				},
+			},
+		},
+	}
+}
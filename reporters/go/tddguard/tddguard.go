@@ -0,0 +1,101 @@
+// Package tddguard reports go test results to TDD Guard from other Go
+// tools. A Reporter reads go test output or individual test events and
+// saves the results where TDD Guard reads them, like the tdd-guard-go
+// command built on it.
+package tddguard
+
+import (
+	"github.com/nizos/tdd-guard/reporters/go/internal/filter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/parser"
+	"github.com/nizos/tdd-guard/reporters/go/internal/project"
+	"github.com/nizos/tdd-guard/reporters/go/internal/reporter"
+	"github.com/nizos/tdd-guard/reporters/go/internal/slow"
+	"github.com/nizos/tdd-guard/reporters/go/internal/transformer"
+	"github.com/nizos/tdd-guard/reporters/go/internal/writer"
+)
+
+// TestEvent is an event of go test -json or go tool test2json
+type TestEvent = parser.TestEvent
+
+// TestResult is the content of test.json
+type TestResult = transformer.TestResult
+
+// TestModule holds the tests of a package
+type TestModule = transformer.TestModule
+
+// Test is the result of a single test
+type Test = transformer.Test
+
+// TestError is an error reported by a failed test
+type TestError = transformer.TestError
+
+// Transitions lists tests that changed state since the previous results
+type Transitions = transformer.Transitions
+
+// Run identifies a reporter invocation that contributed merged results
+type Run = transformer.Run
+
+// SlowPackageTest names the failed test added to a package over its slow
+// budget in strict mode
+const SlowPackageTest = slow.PackageTest
+
+// DefaultPackage is the package of events without one, until SetPackage
+// names another
+const DefaultPackage = parser.DefaultPackage
+
+// FilterMode controls what happens to filtered results
+type FilterMode = filter.Mode
+
+const (
+	// FilterExclude leaves filtered packages and tests out of the results
+	FilterExclude = filter.ModeExclude
+	// FilterSkip keeps filtered tests in the results marked as skipped
+	FilterSkip = filter.ModeSkip
+)
+
+// Filter selects the packages and tests saved in the results. Patterns are
+// globs matched against the full import path or test name, or regular
+// expressions when they start with "re:". Without include patterns
+// everything not excluded is kept.
+type Filter = reporter.Filter
+
+// Sink receives the results of each run once test.json is saved, without
+// the earlier results merged into it. Implementations must not modify the
+// result.
+type Sink = reporter.Sink
+
+// Reporter collects the results of a test run and saves them for TDD Guard.
+// Feed it go test output with Read or events with Add, then call Finish.
+// A Reporter reports a single run and is not safe for concurrent use.
+type Reporter = reporter.Reporter
+
+// NewReporter creates a reporter saving results in the data directory of
+// the project at root. Module IDs and test locations are resolved against
+// the project's modules. An empty root saves relative to the current
+// directory and reports import paths as module IDs.
+func NewReporter(root string) *Reporter {
+	return reporter.NewReporter(root)
+}
+
+// ResolveRoot determines the project root results are saved under, the
+// way tdd-guard-go does: the explicit root, then CLAUDE_PROJECT_DIR, then
+// the nearest ancestor of the current directory with a .claude directory,
+// go.work or go.mod file. Returns an empty string when there is none.
+func ResolveRoot(explicit string) (string, error) {
+	return project.ResolveRoot(explicit)
+}
+
+// NewJUnitSink creates a sink writing results as JUnit XML to path
+func NewJUnitSink(path string) Sink {
+	return writer.NewJUnitWriter(path)
+}
+
+// NewTAPSink creates a sink writing results as TAP version 14 to path
+func NewTAPSink(path string) Sink {
+	return writer.NewTAPWriter(path)
+}
+
+// NewMarkdownSink creates a sink appending a Markdown summary to path
+func NewMarkdownSink(path string) Sink {
+	return writer.NewMarkdownWriter(path)
+}
@@ -0,0 +1,39 @@
+package tddguard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSinks(t *testing.T) {
+	result := &TestResult{Reason: "passed", TestModules: []TestModule{
+		{ModuleID: "calc", Package: "example.com/calc", Tests: []Test{
+			{Name: "TestAdd", FullName: "example.com/calc/TestAdd", State: "passed"},
+		}},
+	}}
+
+	for _, tc := range []struct {
+		name     string
+		sink     func(path string) Sink
+		expected string
+	}{
+		{"JUnit", NewJUnitSink, "<testsuites"},
+		{"TAP", NewTAPSink, "TAP version 14"},
+		{"Markdown", NewMarkdownSink, "Tests passed"},
+	} {
+		t.Run(tc.name+" writes its format to the file", func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "results")
+
+			if err := tc.sink(path).Write(result); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+
+			data, _ := os.ReadFile(path)
+			if !strings.Contains(string(data), tc.expected) {
+				t.Errorf("Expected %q, got:\n%s", tc.expected, data)
+			}
+		})
+	}
+}
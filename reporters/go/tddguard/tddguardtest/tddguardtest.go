@@ -0,0 +1,99 @@
+// Package tddguardtest reports the results of a package's tests to TDD
+// Guard from the test binary itself, so every run of the tests reports,
+// including those an editor starts without piping go test output to
+// tdd-guard-go.
+package tddguardtest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/internal/config"
+	"github.com/nizos/tdd-guard/reporters/go/internal/testbin"
+	"github.com/nizos/tdd-guard/reporters/go/tddguard"
+)
+
+// childEnv is set in the environment of the test binary Main runs, which
+// runs the tests itself instead of starting another copy
+const childEnv = "TDD_GUARD_GO_MAIN_CHILD"
+
+// Main runs the tests of m, saves their results for TDD Guard and exits
+// with the tests' exit code. Call it from TestMain in place of m.Run:
+//
+//	func TestMain(m *testing.M) {
+//		tddguardtest.Main(m)
+//	}
+//
+// Main runs the test binary again verbosely to follow its tests and prints
+// their output as the binary would. Results are merged
+// into the saved results, keeping those of packages tested separately.
+// Other settings come from the config file and TDD_GUARD_GO_* environment
+// variables, as for tdd-guard-go. Invalid settings and failing to save the
+// results are reported on stderr without failing the tests.
+func Main(m *testing.M) {
+	if os.Getenv(childEnv) != "" {
+		os.Exit(m.Run())
+	}
+	os.Exit(run(callerPackage(2), os.Args, os.Stdout, os.Stderr))
+}
+
+// run runs the test binary of args again and reports its results for pkg
+func run(pkg string, args []string, stdout, stderr io.Writer) int {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = append(os.Environ(), childEnv+"=1")
+	cmd.Stdin = os.Stdin
+
+	var code int
+	reporter, err := newReporter(pkg, args[0], stderr)
+	if err != nil {
+		// The tests still run, unreported
+		fmt.Fprintf(stderr, "tdd-guard-go: %v\n", err)
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+		code, err = testbin.ExitCode(cmd.Run())
+	} else {
+		code, err = testbin.Run(cmd, reporter, stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "tdd-guard-go: %v\n", err)
+	}
+	return code
+}
+
+// newReporter creates a reporter for the tests of pkg in the test binary,
+// or returns an error when the settings are invalid
+func newReporter(pkg, binary string, stderr io.Writer) (*tddguard.Reporter, error) {
+	reporter, err := config.LoadReporter("tdd-guard-go")
+	if err != nil {
+		return nil, err
+	}
+
+	// Functions of main packages are named after main, not their import path
+	if pkg == "main" {
+		pkg = binary
+	}
+	if err := reporter.SetPackage(pkg); err != nil {
+		fmt.Fprintf(stderr, "tdd-guard-go: warning: %v, reporting its tests as %s\n", err, reporter.Package())
+	}
+	return reporter, nil
+}
+
+// callerPackage returns the import path of the package calling the
+// function skip frames up, which for an external test package is the
+// package it tests
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return tddguard.DefaultPackage
+	}
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+	return strings.TrimSuffix(name, "_test")
+}
@@ -0,0 +1,91 @@
+package tddguardtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nizos/tdd-guard/reporters/go/tddguard"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("saves the results of the tests for the package", func(t *testing.T) {
+		root := inProject(t)
+		var stdout, stderr bytes.Buffer
+
+		code := run("example.com/calc", []string{os.Args[0], "-test.run=^TestChild$"}, &stdout, &stderr)
+
+		if code != 0 || stdout.String() != "PASS\n" || stderr.Len() > 0 {
+			t.Errorf("Expected a passing run, got exit code %d, output %q and errors %q", code, stdout.String(), stderr.String())
+		}
+		result := readSaved(t, root)
+		module := result.TestModules[0]
+		if module.ModuleID != "example.com/calc" || module.Tests[0].Name != "TestChild" {
+			t.Errorf("Expected TestChild in example.com/calc, got %+v", result)
+		}
+	})
+
+	t.Run("merges with the results of other packages", func(t *testing.T) {
+		root := inProject(t)
+
+		run("example.com/calc", []string{os.Args[0], "-test.run=^TestChild$"}, &bytes.Buffer{}, &bytes.Buffer{})
+		run("example.com/store", []string{os.Args[0], "-test.run=^TestChild$"}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		if result := readSaved(t, root); len(result.TestModules) != 2 {
+			t.Errorf("Expected both packages, got %+v", result)
+		}
+	})
+
+	t.Run("reports invalid settings without failing the tests", func(t *testing.T) {
+		inProject(t)
+		t.Setenv("TDD_GUARD_GO_INCLUDE_TESTS", "re:(")
+		var stderr bytes.Buffer
+
+		code := run("example.com/calc", []string{os.Args[0], "-test.run=^TestChild$"}, &bytes.Buffer{}, &stderr)
+
+		if code != 0 || stderr.Len() == 0 {
+			t.Errorf("Expected a passing run with an error, got exit code %d and errors %q", code, stderr.String())
+		}
+	})
+}
+
+func TestCallerPackage(t *testing.T) {
+	t.Run("returns the import path of the caller", func(t *testing.T) {
+		pkg := callerPackage(1)
+
+		if pkg != "github.com/nizos/tdd-guard/reporters/go/tddguard/tddguardtest" {
+			t.Errorf("Expected this package, got %s", pkg)
+		}
+	})
+}
+
+func TestChild(t *testing.T) {
+	if os.Getenv(childEnv) == "" {
+		t.Skip("runs in the test binary started by TestRun")
+	}
+}
+
+// Helper functions
+
+func inProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("CLAUDE_PROJECT_DIR", root)
+	t.Chdir(root)
+	return root
+}
+
+func readSaved(t *testing.T, root string) *tddguard.TestResult {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, ".claude", "tdd-guard", "data", "test.json"))
+	if err != nil {
+		t.Fatalf("Expected saved results: %v", err)
+	}
+	var result *tddguard.TestResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Invalid saved results: %v", err)
+	}
+	return result
+}